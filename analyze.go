@@ -0,0 +1,42 @@
+package elasticsteps
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (m *Manager) assertAnalyzerTokens(text, analyzer, index, instance, tokens string) error {
+	c, err := m.analyzer(instance)
+	if err != nil {
+		return err
+	}
+
+	actual, err := c.Analyze(m.ctx(), index, text, analyzer)
+	if err != nil {
+		return err
+	}
+
+	return assertTokens(text, splitList(tokens), actual)
+}
+
+func (m *Manager) assertFieldTokens(text, field, index, instance, tokens string) error {
+	c, err := m.analyzer(instance)
+	if err != nil {
+		return err
+	}
+
+	actual, err := c.AnalyzeField(m.ctx(), index, text, field)
+	if err != nil {
+		return err
+	}
+
+	return assertTokens(text, splitList(tokens), actual)
+}
+
+func assertTokens(text string, expected, actual []string) error {
+	if !equalStrings(expected, actual) {
+		return fmt.Errorf("expected %q to produce tokens %s, got %s", text, strings.Join(expected, ", "), strings.Join(actual, ", ")) // nolint: goerr113
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package elasticsteps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_assertAnalyzerTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		tokens        string
+		expectedError string
+	}{
+		{
+			scenario: "failure",
+			mock: mockManager(func(c *client) {
+				c.On("Analyze", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("analyze error"))
+			}),
+			tokens:        "creme, brulee",
+			expectedError: `analyze error`,
+		},
+		{
+			scenario: "different tokens",
+			mock: mockManager(func(c *client) {
+				c.On("Analyze", context.Background(), index, "Crème Brûlée", "folding").
+					Return([]string{"crème", "brûlée"}, nil)
+			}),
+			tokens:        "creme, brulee",
+			expectedError: `expected "Crème Brûlée" to produce tokens creme, brulee, got crème, brûlée`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("Analyze", context.Background(), index, "Crème Brûlée", "folding").
+					Return([]string{"creme", "brulee"}, nil)
+			}),
+			tokens: `"creme", brulee`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertAnalyzerTokens("Crème Brûlée", "folding", index, instance, tc.tokens)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertFieldTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		tokens        string
+		expectedError string
+	}{
+		{
+			scenario: "failure",
+			mock: mockManager(func(c *client) {
+				c.On("AnalyzeField", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("analyze error"))
+			}),
+			tokens:        "creme",
+			expectedError: `analyze error`,
+		},
+		{
+			scenario: "more tokens",
+			mock: mockManager(func(c *client) {
+				c.On("AnalyzeField", context.Background(), index, "Crème Brûlée", "name").
+					Return([]string{"creme", "brulee"}, nil)
+			}),
+			tokens:        "creme",
+			expectedError: `expected "Crème Brûlée" to produce tokens creme, got creme, brulee`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("AnalyzeField", context.Background(), index, "Crème Brûlée", "name").
+					Return([]string{"creme", "brulee"}, nil)
+			}),
+			tokens: "creme, brulee",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertFieldTokens("Crème Brûlée", "name", index, instance, tc.tokens)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cucumber/godog"
+	"github.com/swaggest/assertjson"
+)
+
+// docKey identifies a doc, the ids are unique only within an index.
+type docKey struct {
+	index string
+	id    string
+}
+
+// docSnapshot keeps the sources of all the docs in an index, or in the indices of a pattern or an alias.
+type docSnapshot map[docKey]json.RawMessage
+
+// docChanges represents the changes of an index since a snapshot.
+// nolint: tagliatelle
+type docChanges struct {
+	Added    []changedDoc `json:"added,omitempty"`
+	Removed  []removedDoc `json:"removed,omitempty"`
+	Modified []changedDoc `json:"modified,omitempty"`
+}
+
+// changedDoc is an added or a modified doc, the `_index` is kept only when the docs come from more than one index.
+// nolint: tagliatelle
+type changedDoc struct {
+	Index  string          `json:"_index,omitempty"`
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// removedDoc is a removed doc, it is the id of the doc or, when the docs come from more than one index, its index and
+// its id.
+// nolint: tagliatelle
+type removedDoc struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+// MarshalJSON marshals the id only when there is no index.
+func (d removedDoc) MarshalJSON() ([]byte, error) {
+	if d.Index == "" {
+		return json.Marshal(d.ID)
+	}
+
+	type ref removedDoc
+
+	return json.Marshal(ref(d))
+}
+
+func (m *Manager) takeSnapshot(index, instance, name string) error {
+	docs, err := m.allSources(index, instance)
+	if err != nil {
+		return err
+	}
+
+	m.snapshots[name] = docs
+
+	return nil
+}
+
+func (m *Manager) assertChangedFromSnapshot(index, instance, name string, body *godog.DocString) error {
+	snapshot, ok := m.snapshots[name]
+	if !ok {
+		return fmt.Errorf("snapshot %q not found", name) // nolint: goerr113
+	}
+
+	docs, err := m.allSources(index, instance)
+	if err != nil {
+		return err
+	}
+
+	actual, err := json.Marshal(diffSnapshot(snapshot, docs))
+	if err != nil {
+		return err
+	}
+
+	if err := assertjson.FailNotEqual([]byte(body.Content), actual); err != nil {
+		return fmt.Errorf("failed to compare changes from snapshot %q: %w", name, err)
+	}
+
+	return nil
+}
+
+func (m *Manager) allSources(index, instance string) (docSnapshot, error) {
+	c, err := m.searcher(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := dumpHits(m.ctx(), c, index)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(docSnapshot, len(hits))
+
+	for _, hit := range hits {
+		var (
+			doc  Document
+			meta struct {
+				Index string `json:"_index"`
+			}
+		)
+
+		if err := json.Unmarshal(hit, &doc); err != nil {
+			return nil, fmt.Errorf("could not read search response: %w", err)
+		}
+
+		if err := json.Unmarshal(hit, &meta); err != nil {
+			return nil, fmt.Errorf("could not read search response: %w", err)
+		}
+
+		docs[docKey{index: meta.Index, id: doc.ID}] = doc.Source
+	}
+
+	return docs, nil
+}
+
+func diffSnapshot(before, after docSnapshot) docChanges {
+	var changes docChanges
+
+	// As for the search hits, the index is kept only when the docs come from more than one index.
+	indices := make(map[string]struct{})
+
+	for k := range before {
+		indices[k.index] = struct{}{}
+	}
+
+	for k := range after {
+		indices[k.index] = struct{}{}
+	}
+
+	indexOf := func(k docKey) string {
+		if len(indices) < 2 {
+			return ""
+		}
+
+		return k.index
+	}
+
+	for k, source := range after {
+		prev, ok := before[k]
+
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, changedDoc{Index: indexOf(k), ID: k.id, Source: source})
+
+		case !bytes.Equal(prev, source):
+			changes.Modified = append(changes.Modified, changedDoc{Index: indexOf(k), ID: k.id, Source: source})
+		}
+	}
+
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			changes.Removed = append(changes.Removed, removedDoc{Index: indexOf(k), ID: k.id})
+		}
+	}
+
+	sort.Slice(changes.Added, func(i, j int) bool {
+		return lessDoc(changes.Added[i].Index, changes.Added[i].ID, changes.Added[j].Index, changes.Added[j].ID)
+	})
+	sort.Slice(changes.Modified, func(i, j int) bool {
+		return lessDoc(changes.Modified[i].Index, changes.Modified[i].ID, changes.Modified[j].Index, changes.Modified[j].ID)
+	})
+	sort.Slice(changes.Removed, func(i, j int) bool {
+		return lessDoc(changes.Removed[i].Index, changes.Removed[i].ID, changes.Removed[j].Index, changes.Removed[j].ID)
+	})
+
+	return changes
+}
+
+// lessDoc orders the docs by index, then by id.
+func lessDoc(index1, id1, index2, id2 string) bool {
+	if index1 != index2 {
+		return index1 < index2
+	}
+
+	return id1 < id2
+}
@@ -0,0 +1,192 @@
+package elasticsteps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_assertChangedFromSnapshot(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match_all":{}},"size":10000,"track_total_hits":true,"sort":["_doc"]}`
+
+	before := `{"hits":{"total":{"value":3},"hits":[` +
+		`{"_id":"41","_source":{"name":"Item 41"}},` +
+		`{"_id":"42","_source":{"name":"Item 42"}},` +
+		`{"_id":"43","_source":{"name":"Item 43"}}` +
+		`]}}`
+
+	after := `{"hits":{"total":{"value":3},"hits":[` +
+		`{"_id":"42","_source":{"name": "Item 42"}},` +
+		`{"_id":"43","_source":{"name":"Item 43 (updated)"}},` +
+		`{"_id":"44","_source":{"name":"Item 44"}}` +
+		`]}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		snapshot      string
+		expected      string
+		expectedError string
+	}{
+		{
+			scenario: "snapshot not found",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(before, nil).Once()
+			}),
+			snapshot:      "unknown",
+			expectedError: `snapshot "unknown" not found`,
+		},
+		{
+			scenario: "search error",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(before, nil).Once()
+
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(nil, errors.New("search error")).Once()
+			}),
+			snapshot:      "before",
+			expectedError: `search error`,
+		},
+		{
+			scenario: "different changes",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(before, nil).Once()
+
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(after, nil).Once()
+			}),
+			snapshot: "before",
+			expected: `{"removed":["41"]}`,
+			expectedError: `failed to compare changes from snapshot "before": not equal:
+ {
+   "removed": [
+     "41"
+   ]
++  "added": [
++    {
++      "_id": "44",
++      "_source": {
++        "name": "Item 44"
++      }
++    }
++  ]
++  "modified": [
++    {
++      "_id": "43",
++      "_source": {
++        "name": "Item 43 (updated)"
++      }
++    }
++  ]
+ }
+`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(before, nil).Once()
+
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(after, nil).Once()
+			}),
+			snapshot: "before",
+			expected: `{
+	"added": [{"_id": "44", "_source": {"name": "Item 44"}}],
+	"removed": ["41"],
+	"modified": [{"_id": "43", "_source": {"name": "<ignore-diff>"}}]
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.mock(t)
+
+			assert.NoError(t, m.takeSnapshot(index, instance, "before"))
+
+			err := m.assertChangedFromSnapshot(index, instance, tc.snapshot, &godog.DocString{Content: tc.expected})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_takeSnapshot_TooManyDocs(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", mock.Anything, index, mock.Anything).
+			Return(`{"hits":{"total":{"value":10001,"relation":"eq"},"hits":[]}}`, nil)
+	})(t)
+
+	err := m.takeSnapshot(index, instance, "before")
+
+	assert.EqualError(t, err, `index "test-index" has 10001 docs, more than 10000 docs are not supported`)
+}
+
+func TestManager_assertChangedFromSnapshot_MultiIndex(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match_all":{}},"size":10000,"track_total_hits":true,"sort":["_doc"]}`
+
+	// The same id is in both indices.
+	before := `{"hits":{"total":{"value":3},"hits":[` +
+		`{"_index":"products_en_us","_id":"41","_source":{"name":"Item 41"}},` +
+		`{"_index":"products_fr_fr","_id":"41","_source":{"name":"Article 41"}},` +
+		`{"_index":"products_fr_fr","_id":"42","_source":{"name":"Article 42"}}` +
+		`]}}`
+
+	after := `{"hits":{"total":{"value":3},"hits":[` +
+		`{"_index":"products_en_us","_id":"41","_source":{"name":"Item 41"}},` +
+		`{"_index":"products_en_us","_id":"42","_source":{"name":"Item 42"}},` +
+		`{"_index":"products_fr_fr","_id":"41","_source":{"name":"Article 41 (updated)"}}` +
+		`]}}`
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), "products_*", &query).
+			Return(before, nil).Once()
+
+		c.On("SearchDocuments", context.Background(), "products_*", &query).
+			Return(after, nil).Once()
+	})(t)
+
+	assert.NoError(t, m.takeSnapshot("products_*", instance, "before"))
+
+	err := m.assertChangedFromSnapshot("products_*", instance, "before", &godog.DocString{Content: `{
+	"added": [{"_index": "products_en_us", "_id": "42", "_source": {"name": "Item 42"}}],
+	"removed": [{"_index": "products_fr_fr", "_id": "42"}],
+	"modified": [{"_index": "products_fr_fr", "_id": "41", "_source": {"name": "Article 41 (updated)"}}]
+}`})
+
+	assert.NoError(t, err)
+}
+
+func TestManager_takeSnapshot_TruncatedHits(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", mock.Anything, index, mock.Anything).
+			Return(`{"hits":{"total":{"value":3,"relation":"eq"},"hits":[{"_id":"41","_source":{}}]}}`, nil)
+	})(t)
+
+	err := m.takeSnapshot(index, instance, "before")
+
+	assert.EqualError(t, err, `index "test-index" has at least 3 docs, only 1 are returned`)
+}
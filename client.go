@@ -6,6 +6,10 @@ import (
 )
 
 // Client is an interface for interacting with Elasticsearch.
+//
+// The other interfaces of the package are optional, the steps that need them check whether the client implements them
+// and fail with ErrNotSupported otherwise. See DocumentSearcher, DocumentRemover, DocumentUpdater, DocumentExplainer,
+// Analyzer, SearchTemplateStorer, IndexRefresher, IndexReindexer, HealthChecker, SnapshotManager and LifecycleManager.
 type Client interface {
 	IndexGetter
 	IndexCreator
@@ -31,17 +35,106 @@ type IndexDeleter interface {
 	DeleteIndex(ctx context.Context, indices ...string) error
 }
 
+// IndexRefresher refreshes indices.
+type IndexRefresher interface {
+	RefreshIndex(ctx context.Context, index string) error
+}
+
 // DocumentIndexer indexes documents.
 type DocumentIndexer interface {
 	IndexDocuments(ctx context.Context, index string, documents ...Document) error
 }
 
 // DocumentFinder gets documents.
+//
+// The searches use DocumentSearcher when the client implements it, DocumentFinder is only used by the clients that do
+// not, and the searches with a template need DocumentSearcher.
 type DocumentFinder interface {
 	FindDocuments(ctx context.Context, index string, query *string) ([]json.RawMessage, error)
 }
 
+// DocumentSearcher gets the whole search response.
+type DocumentSearcher interface {
+	SearchDocuments(ctx context.Context, index string, query *string) (json.RawMessage, error)
+	SearchDocumentsByTemplate(ctx context.Context, index string, template string) (json.RawMessage, error)
+}
+
+// DocumentExplainer explains how a document matches a query.
+type DocumentExplainer interface {
+	ExplainDocument(ctx context.Context, index string, id string, query string) (json.RawMessage, error)
+}
+
+// Analyzer analyzes text with an analyzer or with the analyzer of a field.
+type Analyzer interface {
+	Analyze(ctx context.Context, index string, text string, analyzer string) ([]string, error)
+	AnalyzeField(ctx context.Context, index string, text string, field string) ([]string, error)
+}
+
+// SearchTemplateStorer stores search templates.
+type SearchTemplateStorer interface {
+	StoreSearchTemplate(ctx context.Context, id string, source string) error
+}
+
 // DocumentDeleter deletes documents.
 type DocumentDeleter interface {
 	DeleteAllDocuments(ctx context.Context, index string) error
 }
+
+// DocumentRemover deletes a document by its id or the documents matching a query.
+type DocumentRemover interface {
+	DeleteDocument(ctx context.Context, index string, id string) error
+	DeleteDocumentsByQuery(ctx context.Context, index string, query string) error
+}
+
+// DocumentUpdater updates documents with a partial doc or a script.
+type DocumentUpdater interface {
+	UpdateDocument(ctx context.Context, index string, id string, update string) error
+	// UpdateDocumentsByQuery updates the docs matching the query of the body with its script, it waits until the task
+	// completes.
+	UpdateDocumentsByQuery(ctx context.Context, index string, body string) error
+}
+
+// IndexReindexer copies the docs of an index into another one.
+type IndexReindexer interface {
+	// Reindex copies the docs of the source into the destination, it waits until the task completes. The optional
+	// body is a reindex request without the indices, like `{"query": ..., "script": ...}`.
+	Reindex(ctx context.Context, source, dest string, body *string) error
+}
+
+// HealthChecker checks the health of the cluster.
+type HealthChecker interface {
+	// ClusterHealth returns the status of the cluster: green, yellow or red.
+	ClusterHealth(ctx context.Context) (string, error)
+}
+
+// SnapshotManager snapshots and restores indices.
+type SnapshotManager interface {
+	// CreateSnapshotRepository registers a shared file system repository, the location must be in the path.repo setting.
+	CreateSnapshotRepository(ctx context.Context, repository, location string) error
+	// CreateSnapshot snapshots the indices, an existing snapshot with the same name is replaced.
+	CreateSnapshot(ctx context.Context, repository, snapshot string, indices ...string) error
+	// RestoreSnapshot replaces the indices with the ones of the snapshot, the target indices are deleted first.
+	RestoreSnapshot(ctx context.Context, repository, snapshot string, indices ...string) error
+}
+
+// LifecycleState is the index lifecycle management state of an index.
+type LifecycleState struct {
+	Managed    bool   `json:"managed"`
+	Policy     string `json:"policy"`
+	Phase      string `json:"phase"`
+	Action     string `json:"action"`
+	Step       string `json:"step"`
+	FailedStep string `json:"failed_step"`
+}
+
+// LifecycleManager manages the index lifecycle policies.
+type LifecycleManager interface {
+	// PutLifecyclePolicy creates or updates the policy, the body is a `{"policy": ...}` object.
+	PutLifecyclePolicy(ctx context.Context, policy, body string) error
+	// SetLifecyclePolicy attaches the policy to the index.
+	SetLifecyclePolicy(ctx context.Context, index, policy string) error
+	// Rollover creates a new write index for the alias.
+	Rollover(ctx context.Context, alias string) error
+	// ExplainLifecycle returns the current lifecycle state of the index.
+	ExplainLifecycle(ctx context.Context, index string) (LifecycleState, error)
+}
@@ -0,0 +1,160 @@
+// Package main provides a command to dump an index into the fixture files used by the steps, and to list the steps.
+//
+// Usage:
+//
+//	elasticsteps -index products -out resources/fixtures
+//	elasticsteps steps -format json
+//
+// The first command writes `products_config.json` with the mappings and the settings of the index, and
+// `products_docs.json` with all the docs of the index. The second one prints the step definitions as Markdown or JSON.
+//
+// The connection flags -addr, -username, -password, -api-key, -ca-cert and -cloud-id override the ELASTICSTEPS_*
+// environment variables of the default instance, see elasticsearch7.NewManagerFromEnv().
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/godogx/elasticsteps"
+	elasticsearch7 "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+)
+
+type options struct {
+	addresses string
+	username  string
+	password  string
+	apiKey    string
+	caCert    string
+	cloudID   string
+	index     string
+	out       string
+}
+
+// config returns the config of the instance, the flags override the environment.
+func (o options) config() elasticsearch7.InstanceConfig {
+	cfg := elasticsearch7.InstanceConfigFromEnv()
+
+	if o.addresses != "" {
+		cfg.Addresses = strings.Split(o.addresses, ",")
+	}
+
+	for _, f := range []struct {
+		flag  string
+		value *string
+	}{
+		{o.username, &cfg.Username},
+		{o.password, &cfg.Password},
+		{o.apiKey, &cfg.APIKey},
+		{o.caCert, &cfg.CACert},
+		{o.cloudID, &cfg.CloudID},
+	} {
+		if f.flag != "" {
+			*f.value = f.flag
+		}
+	}
+
+	return cfg
+}
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdout)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, err) // nolint: errcheck
+
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "steps" {
+		return runSteps(args[1:], stdout)
+	}
+
+	opts, err := parseOptions(args)
+	if err != nil {
+		return err
+	}
+
+	// Without addresses, the client uses ELASTICSEARCH_URL or http://localhost:9200.
+	c, err := elasticsearch7.NewClientFromConfig(opts.config())
+	if err != nil {
+		return fmt.Errorf("could not create client: %w", err)
+	}
+
+	config, err := elasticsteps.DumpIndexConfig(ctx, c, opts.index)
+	if err != nil {
+		return fmt.Errorf("could not get index %q: %w", opts.index, err)
+	}
+
+	docs, err := elasticsteps.DumpDocuments(ctx, c, opts.index)
+	if err != nil {
+		return fmt.Errorf("could not get docs of index %q: %w", opts.index, err)
+	}
+
+	configFile := filepath.Join(opts.out, opts.index+"_config.json")
+	docsFile := filepath.Join(opts.out, opts.index+"_docs.json")
+
+	if err := writeJSON(configFile, config); err != nil {
+		return err
+	}
+
+	if err := writeJSON(docsFile, docs); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s\n%s\n", configFile, docsFile) // nolint: errcheck
+
+	return nil
+}
+
+func parseOptions(args []string) (options, error) {
+	var opts options
+
+	fs := flag.NewFlagSet("elasticsteps", flag.ContinueOnError)
+
+	fs.StringVar(&opts.addresses, "addr", "", "comma separated list of elasticsearch addresses, defaults to ELASTICSTEPS_ADDRESSES, ELASTICSEARCH_URL or http://localhost:9200")
+	fs.StringVar(&opts.username, "username", "", "the username for basic auth, defaults to ELASTICSTEPS_USERNAME")
+	fs.StringVar(&opts.password, "password", "", "the password for basic auth, defaults to ELASTICSTEPS_PASSWORD")
+	fs.StringVar(&opts.apiKey, "api-key", "", "the base64 encoded api key, defaults to ELASTICSTEPS_API_KEY")
+	fs.StringVar(&opts.caCert, "ca-cert", "", "the PEM encoded ca cert or the path to it, defaults to ELASTICSTEPS_CA_CERT")
+	fs.StringVar(&opts.cloudID, "cloud-id", "", "the id of the elastic cloud deployment, defaults to ELASTICSTEPS_CLOUD_ID")
+	fs.StringVar(&opts.index, "index", "", "the index to dump")
+	fs.StringVar(&opts.out, "out", ".", "the directory of the fixture files")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	if opts.index == "" {
+		return opts, errors.New("missing index") // nolint: goerr113
+	}
+
+	return opts, nil
+}
+
+func writeJSON(path string, value interface{}) error {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("could not write file %q: %w", path, err)
+	}
+
+	return nil
+}
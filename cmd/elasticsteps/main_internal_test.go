@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOptions(t *testing.T) {
+	t.Parallel()
+
+	opts, err := parseOptions([]string{
+		"-addr", "http://es1:9200,http://es2:9200",
+		"-username", "elastic",
+		"-password", "secret",
+		"-api-key", "key",
+		"-ca-cert", "ca.pem",
+		"-cloud-id", "staging:abc",
+		"-index", "products",
+		"-out", "fixtures",
+	})
+	require.NoError(t, err)
+
+	expected := options{
+		addresses: "http://es1:9200,http://es2:9200",
+		username:  "elastic",
+		password:  "secret",
+		apiKey:    "key",
+		caCert:    "ca.pem",
+		cloudID:   "staging:abc",
+		index:     "products",
+		out:       "fixtures",
+	}
+
+	assert.Equal(t, expected, opts)
+
+	_, err = parseOptions([]string{"-addr", "http://es1:9200"})
+	assert.EqualError(t, err, `missing index`)
+
+	_, err = parseOptions([]string{"-unknown"})
+	assert.EqualError(t, err, `flag provided but not defined: -unknown`)
+}
+
+func TestOptions_config(t *testing.T) { // nolint: paralleltest
+	t.Setenv("ELASTICSTEPS_ADDRESSES", "http://env:9200")
+	t.Setenv("ELASTICSTEPS_USERNAME", "env")
+	t.Setenv("ELASTICSTEPS_PASSWORD", "env-secret")
+
+	cfg := options{username: "elastic", index: "products"}.config()
+
+	// The flags override the environment.
+	assert.Equal(t, []string{"http://env:9200"}, cfg.Addresses)
+	assert.Equal(t, "elastic", cfg.Username)
+	assert.Equal(t, "env-secret", cfg.Password)
+}
+
+func TestRunSteps_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	assert.EqualError(t, runSteps([]string{"-format", "yaml"}, &out), `unknown format "yaml"`)
+	assert.Empty(t, out.String())
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if username, password, ok := r.BasicAuth(); !ok || username != "elastic" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+		case "/products":
+			_, _ = w.Write([]byte(`{"products":{"aliases":{},"mappings":{"properties":{"name":{"type":"text"}}},"settings":{"index":{"number_of_shards":"1","uuid":"abc"}}}}`)) // nolint: errcheck
+
+		case "/products/_search":
+			_, _ = w.Write([]byte(`{"hits":{"total":{"value":1,"relation":"eq"},"hits":[{"_index":"products","_id":"41","_source":{"name":"Item 41"}}]}}`)) // nolint: errcheck
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+
+	var out bytes.Buffer
+
+	err := run(context.Background(), []string{"-addr", srv.URL, "-username", "elastic", "-password", "secret", "-index", "products", "-out", dir}, &out)
+	require.NoError(t, err)
+
+	configFile := filepath.Join(dir, "products_config.json")
+	docsFile := filepath.Join(dir, "products_docs.json")
+
+	assert.Equal(t, configFile+"\n"+docsFile+"\n", out.String())
+
+	config, err := os.ReadFile(configFile) // nolint: gosec
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"mappings":{"properties":{"name":{"type":"text"}}},"settings":{"index":{"number_of_shards":"1"}}}`, string(config))
+
+	docs, err := os.ReadFile(docsFile) // nolint: gosec
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[{"_id":"41","_source":{"name":"Item 41"}}]`, string(docs))
+
+	// Without the credentials, es refuses the requests.
+	err = run(context.Background(), []string{"-addr", srv.URL, "-index", "products", "-out", dir}, &out)
+	assert.ErrorContains(t, err, `could not get index "products"`)
+}
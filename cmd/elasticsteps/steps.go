@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/godogx/elasticsteps"
+)
+
+func runSteps(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("elasticsteps steps", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "the output format, markdown or json")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	steps := elasticsteps.NewManager(nil).Steps()
+
+	switch *format {
+	case "markdown":
+		return elasticsteps.WriteStepsMarkdown(stdout, steps)
+
+	case "json":
+		return elasticsteps.WriteStepsJSON(stdout, steps)
+	}
+
+	return fmt.Errorf("unknown format %q", *format) // nolint: goerr113
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bool64/ctxd"
 	es7 "github.com/elastic/go-elasticsearch/v7"
@@ -16,27 +18,114 @@ import (
 	"github.com/godogx/elasticsteps"
 )
 
-var _ elasticsteps.Client = (*Client)(nil)
+var (
+	_ elasticsteps.Client               = (*Client)(nil)
+	_ elasticsteps.DocumentSearcher     = (*Client)(nil)
+	_ elasticsteps.DocumentRemover      = (*Client)(nil)
+	_ elasticsteps.DocumentUpdater      = (*Client)(nil)
+	_ elasticsteps.DocumentExplainer    = (*Client)(nil)
+	_ elasticsteps.Analyzer             = (*Client)(nil)
+	_ elasticsteps.SearchTemplateStorer = (*Client)(nil)
+	_ elasticsteps.IndexRefresher       = (*Client)(nil)
+	_ elasticsteps.IndexReindexer       = (*Client)(nil)
+	_ elasticsteps.HealthChecker        = (*Client)(nil)
+	_ elasticsteps.SnapshotManager      = (*Client)(nil)
+	_ elasticsteps.LifecycleManager     = (*Client)(nil)
+)
 
 // Client is a wrapper around elasticsearch7.Client.
 type Client struct {
-	es *es7.Client
+	es          *es7.Client
+	refresh     Refresh
+	taskTimeout time.Duration
+}
+
+// bulkFlushBytes is the max size of a bulk request body, it is the default flush size of esutil.BulkIndexer and it is
+// far below the default http.max_content_length of es.
+const bulkFlushBytes = 5 << 20
+
+// ClientOption sets up the client.
+type ClientOption func(c *Client)
+
+// Refresh is the refresh policy for indexing, updating and deleting documents.
+type Refresh string
+
+const (
+	// RefreshTrue refreshes the affected shards immediately.
+	RefreshTrue Refresh = "true"
+	// RefreshWaitFor waits for the next refresh of the affected shards.
+	RefreshWaitFor Refresh = "wait_for"
+	// RefreshFalse does not refresh, the changes are visible after the next refresh.
+	RefreshFalse Refresh = "false"
+)
+
+// NewClient wraps the elasticsearch7.Client.
+func NewClient(client *es7.Client, opts ...ClientOption) *Client {
+	c := &Client{
+		es:          instrument(client),
+		refresh:     RefreshTrue,
+		taskTimeout: DefaultTaskTimeout,
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// WithRefresh sets the refresh policy, default is RefreshTrue. It panics if the policy is not one of RefreshTrue,
+// RefreshWaitFor or RefreshFalse, use ParseRefresh() to validate a policy from the configuration.
+func WithRefresh(refresh Refresh) ClientOption {
+	if _, err := ParseRefresh(string(refresh)); err != nil {
+		panic(err)
+	}
+
+	return func(c *Client) {
+		c.refresh = refresh
+	}
+}
+
+// ParseRefresh returns the refresh policy, it fails with ErrInvalidRefresh if the policy is not `true`, `wait_for` or
+// `false`.
+func ParseRefresh(s string) (Refresh, error) {
+	switch r := Refresh(s); r {
+	case RefreshTrue, RefreshWaitFor, RefreshFalse:
+		return r, nil
+	}
+
+	return "", fmt.Errorf("%w: %q, expected true, wait_for or false", ErrInvalidRefresh, s)
+}
+
+// RefreshIndex satisfies elasticsteps.IndexRefresher.
+func (c *Client) RefreshIndex(ctx context.Context, index string) error {
+	refresh := c.es.Indices.Refresh
+
+	_, err := refineResp(refresh(
+		refresh.WithContext(ctx),
+		refresh.WithIndex(index),
+	))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not refresh index", "index", index)
+	}
+
+	return nil
 }
 
 // GetIndex satisfies elasticsteps.Client.
 func (c *Client) GetIndex(ctx context.Context, index string) (json.RawMessage, error) {
 	get := c.es.Indices.Get
 
-	_, err := refineResp(get([]string{index}, get.WithContext(ctx)))
+	resp, err := refineResp(get([]string{index}, get.WithContext(ctx)))
 	if err != nil {
-		if err.code == http.StatusNotFound {
+		if err.Code == http.StatusNotFound {
 			return nil, elasticsteps.ErrIndexNotFound
 		}
 
 		return nil, err
 	}
 
-	return nil, nil
+	return readBody(ctx, resp)
 }
 
 // CreateIndex satisfies elasticsteps.Client.
@@ -75,7 +164,7 @@ func (c *Client) DeleteIndex(ctx context.Context, indices ...string) error {
 
 	_, err := refineResp(del(indices, del.WithContext(ctx)))
 	if err != nil {
-		if err.code == http.StatusNotFound {
+		if err.Code == http.StatusNotFound {
 			return nil
 		}
 
@@ -86,52 +175,161 @@ func (c *Client) DeleteIndex(ctx context.Context, indices ...string) error {
 }
 
 // IndexDocuments satisfies elasticsteps.Client.
+//
+// The docs are sent in bulk requests of at most bulkFlushBytes, one after another, so they are applied in order,
+// including the ones with optimistic concurrency control.
 func (c *Client) IndexDocuments(ctx context.Context, index string, docs ...elasticsteps.Document) error {
-	indexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
-		Client:  c.es,
-		Index:   index,
-		Refresh: "true",
-	})
+	if len(docs) == 0 {
+		return nil
+	}
+
+	bodies, err := bulkBodies(docs, bulkFlushBytes)
 	if err != nil {
-		return ctxd.WrapError(ctx, err, "could not init bulk indexer", "index", index)
+		return ctxd.WrapError(ctx, err, "could not marshal bulk request", "index", index)
 	}
 
-	for _, doc := range docs {
-		doc := doc
+	var result esutil.BulkIndexerResponse
+
+	for _, body := range bodies {
+		items, err := c.bulk(ctx, index, body)
+		if err != nil {
+			return err
+		}
+
+		result.Items = append(result.Items, items...)
+	}
+
+	return bulkError(result)
+}
+
+func (c *Client) bulk(ctx context.Context, index string, body []byte) ([]map[string]esutil.BulkIndexerResponseItem, error) {
+	bulk := c.es.Bulk
+
+	resp, rErr := refineResp(bulk(bytes.NewReader(body),
+		bulk.WithContext(ctx),
+		bulk.WithIndex(index),
+		bulk.WithRefresh(string(c.refresh)),
+	))
+	if rErr != nil {
+		return nil, ctxd.WrapError(ctx, rErr, "could not index documents", "index", index)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result esutil.BulkIndexerResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not unmarshal bulk response", "index", index)
+	}
+
+	return result.Items, nil
+}
+
+// bulkAction is the action and metadata line of a doc in a bulk request.
+// nolint: tagliatelle
+type bulkAction struct {
+	ID            string `json:"_id,omitempty"`
+	Routing       string `json:"routing,omitempty"`
+	Version       *int64 `json:"version,omitempty"`
+	VersionType   string `json:"version_type,omitempty"`
+	IfSeqNo       *int64 `json:"if_seq_no,omitempty"`
+	IfPrimaryTerm *int64 `json:"if_primary_term,omitempty"`
+}
+
+// bulkBodies splits the docs into bulk request bodies of at most maxBytes, a doc bigger than maxBytes is sent alone.
+func bulkBodies(docs []elasticsteps.Document, maxBytes int) ([][]byte, error) {
+	var (
+		bodies [][]byte
+		buf    bytes.Buffer
+		item   bytes.Buffer
+	)
 
-		err := indexer.Add(ctx, esutil.BulkIndexerItem{
-			Index:      index,
-			Action:     "index",
-			DocumentID: doc.ID,
-			Body:       bytes.NewReader(doc.Source),
+	for _, doc := range docs {
+		item.Reset()
+
+		action, err := json.Marshal(map[string]bulkAction{
+			opType(doc): {
+				ID:            doc.ID,
+				Routing:       doc.Routing,
+				Version:       doc.Version,
+				VersionType:   doc.VersionType,
+				IfSeqNo:       doc.IfSeqNo,
+				IfPrimaryTerm: doc.IfPrimaryTerm,
+			},
 		})
 		if err != nil {
-			return ctxd.WrapError(ctx, err, "could not add doc to bulk indexer",
-				"index", index, "doc", doc,
-			)
+			return nil, err
 		}
+
+		item.Write(action)
+		item.WriteByte('\n')
+
+		// The source must be on one line.
+		if err := json.Compact(&item, doc.Source); err != nil {
+			return nil, err
+		}
+
+		item.WriteByte('\n')
+
+		if buf.Len() > 0 && buf.Len()+item.Len() > maxBytes {
+			bodies = append(bodies, append([]byte(nil), buf.Bytes()...))
+
+			buf.Reset()
+		}
+
+		buf.Write(item.Bytes())
 	}
 
-	if err := indexer.Close(ctx); err != nil {
-		return ctxd.WrapError(ctx, err, "could not close bulk indexer",
-			"index", index,
-		)
+	if buf.Len() > 0 {
+		bodies = append(bodies, buf.Bytes())
 	}
 
-	stats := indexer.Stats()
+	return bodies, nil
+}
 
-	if stats.NumFailed > 0 {
-		return ctxd.NewError(ctx, "could not index all documents",
-			"num_docs", stats.NumRequests,
-			"num_failure", stats.NumFailed,
-		)
+func bulkError(resp esutil.BulkIndexerResponse) error {
+	var failures []elasticsteps.BulkFailure
+
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status < http.StatusMultipleChoices {
+				continue
+			}
+
+			failures = append(failures, elasticsteps.BulkFailure{
+				ID:     result.DocumentID,
+				Status: result.Status,
+				Type:   result.Error.Type,
+				Reason: result.Error.Reason,
+			})
+		}
 	}
 
-	return nil
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return &elasticsteps.BulkError{Failures: failures}
 }
 
 // FindDocuments satisfies elasticsteps.Client.
 func (c *Client) FindDocuments(ctx context.Context, index string, query *string) ([]json.RawMessage, error) {
+	resp, err := c.SearchDocuments(ctx, index, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result elasticsteps.SearchResult
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not unmarshal all documents", "index", index)
+	}
+
+	return result.Hits.Hits, nil
+}
+
+// SearchDocuments satisfies elasticsteps.DocumentSearcher.
+func (c *Client) SearchDocuments(ctx context.Context, index string, query *string) (json.RawMessage, error) {
 	search := c.es.Search
 
 	var body string
@@ -144,45 +342,276 @@ func (c *Client) FindDocuments(ctx context.Context, index string, query *string)
 
 	resp, err := refineResp(search(
 		search.WithContext(ctx),
-		search.WithIndex(index),
+		search.WithIndex(indices(index)...),
 		search.WithBody(strings.NewReader(body)),
 	))
 	if err != nil {
 		return nil, ctxd.WrapError(ctx, err, "could not get all documents", "index", index)
 	}
 
-	var result elasticsteps.SearchResult
+	return readBody(ctx, resp)
+}
+
+// SearchDocumentsByTemplate satisfies elasticsteps.DocumentSearcher.
+func (c *Client) SearchDocumentsByTemplate(ctx context.Context, index string, template string) (json.RawMessage, error) {
+	search := c.es.SearchTemplate
+
+	resp, err := refineResp(search(strings.NewReader(template),
+		search.WithContext(ctx),
+		search.WithIndex(indices(index)...),
+	))
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not search documents by template", "index", index)
+	}
+
+	return readBody(ctx, resp)
+}
+
+// ExplainDocument satisfies elasticsteps.DocumentExplainer.
+func (c *Client) ExplainDocument(ctx context.Context, index string, id string, query string) (json.RawMessage, error) {
+	explain := c.es.Explain
+
+	resp, err := refineResp(explain(index, id,
+		explain.WithContext(ctx),
+		explain.WithBody(strings.NewReader(query)),
+	))
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not explain document", "index", index, "id", id)
+	}
+
+	return readBody(ctx, resp)
+}
+
+// Analyze satisfies elasticsteps.Analyzer.
+func (c *Client) Analyze(ctx context.Context, index string, text string, analyzer string) ([]string, error) {
+	return c.analyze(ctx, index, map[string]string{"text": text, "analyzer": analyzer})
+}
+
+// AnalyzeField satisfies elasticsteps.Analyzer.
+func (c *Client) AnalyzeField(ctx context.Context, index string, text string, field string) ([]string, error) {
+	return c.analyze(ctx, index, map[string]string{"text": text, "field": field})
+}
+
+func (c *Client) analyze(ctx context.Context, index string, req map[string]string) ([]string, error) {
+	analyze := c.es.Indices.Analyze
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not marshal analyze request", "index", index)
+	}
+
+	resp, rErr := refineResp(analyze(
+		analyze.WithContext(ctx),
+		analyze.WithIndex(index),
+		analyze.WithBody(bytes.NewReader(body)),
+	))
+	if rErr != nil {
+		return nil, ctxd.WrapError(ctx, rErr, "could not analyze text", "index", index)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result struct {
+		Tokens []struct {
+			Token string `json:"token"`
+		} `json:"tokens"`
+	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, ctxd.WrapError(ctx, err, "could not unmarshal all documents", "index", index)
+		return nil, ctxd.WrapError(ctx, err, "could not unmarshal tokens", "index", index)
 	}
 
-	return result.Hits.Hits, nil
+	tokens := make([]string, len(result.Tokens))
+
+	for i, t := range result.Tokens {
+		tokens[i] = t.Token
+	}
+
+	return tokens, nil
+}
+
+// StoreSearchTemplate satisfies elasticsteps.SearchTemplateStorer.
+func (c *Client) StoreSearchTemplate(ctx context.Context, id string, source string) error {
+	put := c.es.PutScript
+
+	// The source is either a json object or a string which is useful for the templates that are not valid json.
+	src := json.RawMessage(source)
+
+	if !json.Valid(src) {
+		src, _ = json.Marshal(source) // nolint: errcheck,errchkjson
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"script": map[string]interface{}{
+			"lang":   "mustache",
+			"source": src,
+		},
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not marshal search template", "id", id)
+	}
+
+	if _, err := refineResp(put(id, bytes.NewReader(body), put.WithContext(ctx))); err != nil {
+		return ctxd.WrapError(ctx, err, "could not store search template", "id", id)
+	}
+
+	return nil
 }
 
 // DeleteAllDocuments satisfies elasticsteps.Client.
+//
+// The version conflicts are ignored, the docs that changed meanwhile are kept.
 func (c *Client) DeleteAllDocuments(ctx context.Context, index string) error {
+	resp, err := c.deleteByQuery(ctx, index, `{"query": {"match_all":{}}}`, "proceed")
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// DeleteDocument satisfies elasticsteps.DocumentRemover.
+func (c *Client) DeleteDocument(ctx context.Context, index string, id string) error {
+	del := c.es.Delete
+
+	_, err := refineResp(del(index, id,
+		del.WithContext(ctx),
+		del.WithRefresh(string(c.refresh)),
+	))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not delete document", "index", index, "id", id)
+	}
+
+	return nil
+}
+
+// DeleteDocumentsByQuery satisfies elasticsteps.DocumentRemover.
+//
+// It fails if any doc could not be deleted, including the version conflicts.
+func (c *Client) DeleteDocumentsByQuery(ctx context.Context, index string, query string) error {
+	resp, err := c.deleteByQuery(ctx, index, query, "abort")
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result deleteByQueryResult
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ctxd.WrapError(ctx, err, "could not unmarshal delete by query response", "index", index)
+	}
+
+	if err := result.err(); err != nil {
+		return ctxd.WrapError(ctx, err, "could not delete documents by query", "index", index)
+	}
+
+	return nil
+}
+
+func (c *Client) deleteByQuery(ctx context.Context, index string, query string, conflicts string) (*esapi.Response, error) {
 	deleteByQuery := c.es.DeleteByQuery
-	query := `{"query": {"match_all":{}}}`
 
-	_, err := refineResp(deleteByQuery(
+	resp, err := refineResp(deleteByQuery(
 		[]string{index}, strings.NewReader(query),
 		deleteByQuery.WithContext(ctx),
-		deleteByQuery.WithRefresh(true),
-		deleteByQuery.WithConflicts("proceed"),
+		// Delete by query does not support wait_for.
+		deleteByQuery.WithRefresh(c.refresh != RefreshFalse),
+		deleteByQuery.WithConflicts(conflicts),
 	))
 	if err != nil {
-		return ctxd.WrapError(ctx, err, "could not delete all documents", "index", index)
+		return nil, ctxd.WrapError(ctx, err, "could not delete documents by query", "index", index)
+	}
+
+	return resp, nil
+}
+
+// nolint: tagliatelle
+type deleteByQueryResult struct {
+	VersionConflicts int               `json:"version_conflicts"`
+	Failures         []json.RawMessage `json:"failures"`
+}
+
+func (r deleteByQueryResult) err() error {
+	if n := len(r.Failures); n > 0 {
+		return newError(codeUnknown, fmt.Sprintf("%d failures, the first one is %s", n, r.Failures[0]))
+	}
+
+	if r.VersionConflicts > 0 {
+		return newError(http.StatusConflict, fmt.Sprintf("%d version conflicts", r.VersionConflicts))
+	}
+
+	return nil
+}
+
+// UpdateDocument satisfies elasticsteps.DocumentUpdater.
+func (c *Client) UpdateDocument(ctx context.Context, index string, id string, update string) error {
+	upd := c.es.Update
+
+	_, err := refineResp(upd(index, id, strings.NewReader(update),
+		upd.WithContext(ctx),
+		upd.WithRefresh(string(c.refresh)),
+	))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not update document", "index", index, "id", id)
 	}
 
 	return nil
 }
 
-func wrapClient(client *es7.Client) *Client {
-	return &Client{es: client}
+func opType(doc elasticsteps.Document) string {
+	if doc.OpType != "" {
+		return doc.OpType
+	}
+
+	return "index"
+}
+
+// ClusterHealth satisfies elasticsteps.HealthChecker.
+func (c *Client) ClusterHealth(ctx context.Context) (string, error) {
+	health := c.es.Cluster.Health
+
+	resp, err := refineResp(health(health.WithContext(ctx)))
+	if err != nil {
+		return "", ctxd.WrapError(ctx, err, "could not get cluster health")
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result struct {
+		Status string `json:"status"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", ctxd.WrapError(ctx, err, "could not unmarshal cluster health")
+	}
+
+	return result.Status, nil
+}
+
+// indices splits a comma-separated list of indices, aliases or patterns.
+func indices(index string) []string {
+	result := strings.Split(index, ",")
+
+	for i, idx := range result {
+		result[i] = strings.TrimSpace(idx)
+	}
+
+	return result
+}
+
+func readBody(ctx context.Context, resp *esapi.Response) (json.RawMessage, error) {
+	defer resp.Body.Close() // nolint: errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not read response")
+	}
+
+	return body, nil
 }
 
-func refineResp(resp *esapi.Response, err error) (*esapi.Response, *err) {
+func refineResp(resp *esapi.Response, err error) (*esapi.Response, *Error) {
 	if err != nil {
 		return nil, newError(codeUnknown, err.Error())
 	}
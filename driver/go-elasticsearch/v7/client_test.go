@@ -0,0 +1,248 @@
+package elasticsearch7_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	es7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/godogx/elasticsteps"
+	elasticsearch7 "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+)
+
+func TestClient_IndexDocuments(t *testing.T) {
+	t.Parallel()
+
+	var body string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+		case "/products/_bulk":
+			b, _ := io.ReadAll(r.Body) // nolint: errcheck
+			body = string(b)
+
+			assert.Equal(t, "true", r.URL.Query().Get("refresh"))
+
+			_, _ = w.Write([]byte(`{"errors":true,"items":[` + // nolint: errcheck
+				`{"index":{"_id":"42","status":200}},` +
+				`{"index":{"_id":"42","status":409,"error":{"type":"version_conflict_engine_exception","reason":"version conflict"}}},` +
+				`{"create":{"_id":"43","status":201}}` +
+				`]}`))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	var docs []elasticsteps.Document
+
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"_id": "42", "_source": {"name": "Item 42"}},
+		{"_id": "42", "_source": {"name": "Item 42 (updated)"}, "if_seq_no": 0, "if_primary_term": 1},
+		{"_id": "43", "_source": {"name": "Item 43"}, "_routing": "tenant", "_version": 3, "_version_type": "external", "_op_type": "create"}
+	]`), &docs))
+
+	err = elasticsearch7.NewClient(es).IndexDocuments(context.Background(), "products", docs...)
+
+	expectedBody := `{"index":{"_id":"42"}}
+{"name":"Item 42"}
+{"index":{"_id":"42","if_seq_no":0,"if_primary_term":1}}
+{"name":"Item 42 (updated)"}
+{"create":{"_id":"43","routing":"tenant","version":3,"version_type":"external"}}
+{"name":"Item 43"}
+`
+
+	assert.Equal(t, expectedBody, body)
+
+	expectedErr := &elasticsteps.BulkError{Failures: []elasticsteps.BulkFailure{{
+		ID:     "42",
+		Status: http.StatusConflict,
+		Type:   "version_conflict_engine_exception",
+		Reason: "version conflict",
+	}}}
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestClient_IndexDocuments_Batches(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu  sync.Mutex
+		ids [][]string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body) // nolint: errcheck
+		lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+
+		var (
+			batch []string
+			items []string
+		)
+
+		// The action lines are the even ones, the docs "42" fail.
+		for i := 0; i < len(lines); i += 2 {
+			var action map[string]struct {
+				ID string `json:"_id"`
+			}
+
+			require.NoError(t, json.Unmarshal([]byte(lines[i]), &action))
+
+			id := action["index"].ID
+			batch = append(batch, id)
+
+			if id == "42" {
+				items = append(items, `{"index":{"_id":"42","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}`)
+			} else {
+				items = append(items, `{"index":{"_id":"`+id+`","status":201}}`)
+			}
+		}
+
+		mu.Lock()
+		ids = append(ids, batch)
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"errors":true,"items":[` + strings.Join(items, ",") + `]}`)) // nolint: errcheck
+	}))
+
+	t.Cleanup(srv.Close)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	// 3 docs of 2 MB do not fit in one bulk request of 5 MB.
+	source := json.RawMessage(`{"name":"` + strings.Repeat("a", 2<<20) + `"}`)
+
+	err = elasticsearch7.NewClient(es).IndexDocuments(context.Background(), "products",
+		elasticsteps.Document{ID: "41", Source: source},
+		elasticsteps.Document{ID: "42", Source: source},
+		elasticsteps.Document{ID: "43", Source: source},
+		elasticsteps.Document{ID: "42", Source: source},
+	)
+
+	assert.Equal(t, [][]string{{"41", "42"}, {"43", "42"}}, ids)
+
+	failure := elasticsteps.BulkFailure{
+		ID:     "42",
+		Status: http.StatusBadRequest,
+		Type:   "mapper_parsing_exception",
+		Reason: "failed to parse",
+	}
+
+	assert.Equal(t, &elasticsteps.BulkError{Failures: []elasticsteps.BulkFailure{failure, failure}}, err)
+}
+
+func newDeleteByQueryServer(t *testing.T, result string, conflicts *string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+		case "/products/_delete_by_query":
+			*conflicts = r.URL.Query().Get("conflicts")
+
+			_, _ = w.Write([]byte(result)) // nolint: errcheck
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient_DeleteDocumentsByQuery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		result        string
+		expectedError string
+	}{
+		{
+			scenario: "all deleted",
+			result:   `{"deleted":2,"version_conflicts":0,"failures":[]}`,
+		},
+		{
+			scenario:      "version conflicts",
+			result:        `{"deleted":1,"version_conflicts":1,"failures":[]}`,
+			expectedError: `could not delete documents by query: 1 version conflicts`,
+		},
+		{
+			scenario:      "failures",
+			result:        `{"deleted":1,"version_conflicts":0,"failures":[{"id":"42","cause":{"type":"es_rejected_execution_exception"}}]}`,
+			expectedError: `could not delete documents by query: 1 failures, the first one is {"id":"42","cause":{"type":"es_rejected_execution_exception"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			var conflicts string
+
+			es, err := es7.NewClient(es7.Config{Addresses: []string{newDeleteByQueryServer(t, tc.result, &conflicts).URL}})
+			require.NoError(t, err)
+
+			err = elasticsearch7.NewClient(es).DeleteDocumentsByQuery(context.Background(), "products", `{"query":{"match_all":{}}}`)
+
+			assert.Equal(t, "abort", conflicts)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteAllDocuments(t *testing.T) {
+	t.Parallel()
+
+	var conflicts string
+
+	// The version conflicts are ignored.
+	es, err := es7.NewClient(es7.Config{Addresses: []string{newDeleteByQueryServer(t, `{"deleted":1,"version_conflicts":1,"failures":[]}`, &conflicts).URL}})
+	require.NoError(t, err)
+
+	assert.NoError(t, elasticsearch7.NewClient(es).DeleteAllDocuments(context.Background(), "products"))
+	assert.Equal(t, "proceed", conflicts)
+}
@@ -0,0 +1,182 @@
+package elasticsearch7
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	es7 "github.com/elastic/go-elasticsearch/v7"
+	"gopkg.in/yaml.v3"
+
+	"github.com/godogx/elasticsteps"
+)
+
+const (
+	envConfigFile = "ELASTICSTEPS_CONFIG"
+	envInstances  = "ELASTICSTEPS_INSTANCES"
+	envPrefix     = "ELASTICSTEPS_"
+)
+
+// Config defines the default and the named es instances of a manager.
+type Config struct {
+	Default   InstanceConfig            `yaml:"default"`
+	Instances map[string]InstanceConfig `yaml:"instances"`
+}
+
+// InstanceConfig defines how to connect to an es instance.
+type InstanceConfig struct {
+	// Addresses defaults to ELASTICSEARCH_URL or http://localhost:9200.
+	Addresses []string `yaml:"addresses"`
+	Username  string   `yaml:"username"`
+	Password  string   `yaml:"password"`
+	APIKey    string   `yaml:"api_key"`
+	// CACert is a PEM encoded certificate or the path to it.
+	CACert  string `yaml:"ca_cert"`
+	CloudID string `yaml:"cloud_id"`
+	// Refresh defaults to RefreshTrue, the other values are RefreshWaitFor and RefreshFalse.
+	Refresh Refresh `yaml:"refresh"`
+}
+
+// NewManagerFromConfig initiates a new data manager with the es instances of the config.
+func NewManagerFromConfig(cfg Config, opts ...elasticsteps.ManagerOption) (*elasticsteps.Manager, error) {
+	c, err := NewClientFromConfig(cfg.Default)
+	if err != nil {
+		return nil, fmt.Errorf("could not create default instance: %w", err)
+	}
+
+	instances := make([]elasticsteps.ManagerOption, 0, len(cfg.Instances)+len(opts))
+
+	for name, instance := range cfg.Instances {
+		c, err := NewClientFromConfig(instance)
+		if err != nil {
+			return nil, fmt.Errorf("could not create instance %q: %w", name, err)
+		}
+
+		instances = append(instances, elasticsteps.WithInstance(name, c))
+	}
+
+	return elasticsteps.NewManager(c, append(instances, opts...)...), nil
+}
+
+// NewManagerFromFile initiates a new data manager with the es instances of a YAML config file.
+//
+//	default:
+//	    addresses: [ "http://127.0.0.1:9200" ]
+//	instances:
+//	    logs:
+//	        cloud_id: "logs:ZXUtd2VzdC0x..."
+//	        api_key: "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw=="
+func NewManagerFromFile(path string, opts ...elasticsteps.ManagerOption) (*elasticsteps.Manager, error) {
+	data, err := os.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not read config from file %q: %w", path, err)
+	}
+
+	var cfg Config
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not read config from file %q: %w", path, err)
+	}
+
+	return NewManagerFromConfig(cfg, opts...)
+}
+
+// NewManagerFromEnv initiates a new data manager with the es instances of the environment.
+//
+// When ELASTICSTEPS_CONFIG is set, the config is read from this YAML file. Otherwise, the default instance is defined
+// by ELASTICSTEPS_ADDRESSES (comma separated), ELASTICSTEPS_USERNAME, ELASTICSTEPS_PASSWORD, ELASTICSTEPS_API_KEY,
+// ELASTICSTEPS_CA_CERT, ELASTICSTEPS_CLOUD_ID and ELASTICSTEPS_REFRESH. ELASTICSTEPS_INSTANCES lists the names of the
+// other instances (comma separated), which are defined by the same variables with the upper cased name after the
+// prefix, for example ELASTICSTEPS_LOGS_ADDRESSES for the instance "logs".
+func NewManagerFromEnv(opts ...elasticsteps.ManagerOption) (*elasticsteps.Manager, error) {
+	if path := os.Getenv(envConfigFile); path != "" {
+		return NewManagerFromFile(path, opts...)
+	}
+
+	cfg := Config{
+		Default:   InstanceConfigFromEnv(),
+		Instances: make(map[string]InstanceConfig),
+	}
+
+	for _, name := range splitList(os.Getenv(envInstances)) {
+		prefix := envPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name)) + "_"
+
+		cfg.Instances[name] = instanceConfigFromEnv(prefix)
+	}
+
+	return NewManagerFromConfig(cfg, opts...)
+}
+
+// InstanceConfigFromEnv returns the config of the default instance from the environment, see NewManagerFromEnv().
+func InstanceConfigFromEnv() InstanceConfig {
+	return instanceConfigFromEnv(envPrefix)
+}
+
+func instanceConfigFromEnv(prefix string) InstanceConfig {
+	return InstanceConfig{
+		Addresses: splitList(os.Getenv(prefix + "ADDRESSES")),
+		Username:  os.Getenv(prefix + "USERNAME"),
+		Password:  os.Getenv(prefix + "PASSWORD"),
+		APIKey:    os.Getenv(prefix + "API_KEY"),
+		CACert:    os.Getenv(prefix + "CA_CERT"),
+		CloudID:   os.Getenv(prefix + "CLOUD_ID"),
+		Refresh:   Refresh(os.Getenv(prefix + "REFRESH")),
+	}
+}
+
+// NewClientFromConfig creates the client of an es instance, it fails with ErrInvalidRefresh if the refresh policy is
+// not valid.
+func NewClientFromConfig(cfg InstanceConfig) (*Client, error) {
+	esCfg := es7.Config{
+		Addresses: cfg.Addresses,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		APIKey:    cfg.APIKey,
+		CloudID:   cfg.CloudID,
+	}
+
+	switch {
+	case cfg.CACert == "":
+
+	case strings.HasPrefix(strings.TrimSpace(cfg.CACert), "-----BEGIN"):
+		esCfg.CACert = []byte(cfg.CACert)
+
+	default:
+		cert, err := os.ReadFile(cfg.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ca cert: %w", err)
+		}
+
+		esCfg.CACert = cert
+	}
+
+	es, err := es7.NewClient(esCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []ClientOption
+
+	if cfg.Refresh != "" {
+		refresh, err := ParseRefresh(string(cfg.Refresh))
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, WithRefresh(refresh))
+	}
+
+	return NewClient(es, opts...), nil
+}
+
+func splitList(s string) []string {
+	var result []string
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,149 @@
+package elasticsearch7_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	elasticsearch7 "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+)
+
+func TestNewManagerFromFile(t *testing.T) {
+	t.Parallel()
+
+	srv := newRequestRecorder(t)
+	extra := newRequestRecorder(t)
+
+	path := filepath.Join(t.TempDir(), "elasticsteps.yaml")
+	config := `
+default:
+    addresses: [ "` + srv.URL + `" ]
+instances:
+    extra:
+        addresses: [ "` + extra.URL + `" ]
+        username: elastic
+        password: secret
+    norefresh:
+        addresses: [ "` + srv.URL + `" ]
+        refresh: false
+`
+
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	m, err := elasticsearch7.NewManagerFromFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"_default", "extra", "norefresh"}, m.Instances())
+
+	runScenario(t, m, `
+    Given doc "41" is deleted from index "products"
+    And doc "42" is deleted from index "products" of es "extra"
+    And doc "43" is deleted from index "products" of es "norefresh"
+`)
+
+	requests := srv.Requests()
+
+	require.Len(t, requests, 2)
+
+	assert.Equal(t, "/products/_doc/41", requests[0].URL.Path)
+	assert.Equal(t, "true", requests[0].URL.Query().Get("refresh"))
+	assert.Empty(t, requests[0].Header.Get("Authorization"))
+
+	assert.Equal(t, "/products/_doc/43", requests[1].URL.Path)
+	assert.Equal(t, "false", requests[1].URL.Query().Get("refresh"))
+
+	requests = extra.Requests()
+
+	require.Len(t, requests, 1)
+
+	username, password, ok := requests[0].BasicAuth()
+
+	assert.True(t, ok)
+	assert.Equal(t, "elastic", username)
+	assert.Equal(t, "secret", password)
+}
+
+func TestNewManagerFromFile_Error(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "elasticsteps.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`instances: [extra]`), 0o600))
+
+	_, err := elasticsearch7.NewManagerFromFile(path)
+	assert.ErrorContains(t, err, `could not read config from file`)
+
+	require.NoError(t, os.WriteFile(path, []byte(`instances: {extra: {ca_cert: unknown.pem}}`), 0o600))
+
+	_, err = elasticsearch7.NewManagerFromFile(path)
+	assert.EqualError(t, err, `could not create instance "extra": could not read ca cert: open unknown.pem: no such file or directory`)
+}
+
+func TestNewManagerFromConfig_InvalidRefresh(t *testing.T) {
+	t.Parallel()
+
+	_, err := elasticsearch7.NewManagerFromConfig(elasticsearch7.Config{
+		Default: elasticsearch7.InstanceConfig{Refresh: "yes"},
+	})
+
+	assert.ErrorIs(t, err, elasticsearch7.ErrInvalidRefresh)
+	assert.EqualError(t, err, `could not create default instance: invalid refresh policy: "yes", expected true, wait_for or false`)
+
+	_, err = elasticsearch7.NewManagerFromConfig(elasticsearch7.Config{
+		Instances: map[string]elasticsearch7.InstanceConfig{"extra": {Refresh: "no"}},
+	})
+
+	assert.EqualError(t, err, `could not create instance "extra": invalid refresh policy: "no", expected true, wait_for or false`)
+}
+
+func TestNewManagerFromEnv(t *testing.T) { // nolint: paralleltest
+	srv := newRequestRecorder(t)
+	extra := newRequestRecorder(t)
+
+	t.Setenv("ELASTICSTEPS_ADDRESSES", srv.URL)
+	t.Setenv("ELASTICSTEPS_REFRESH", "wait_for")
+	t.Setenv("ELASTICSTEPS_INSTANCES", "extra, no-refresh")
+	t.Setenv("ELASTICSTEPS_EXTRA_ADDRESSES", extra.URL)
+	t.Setenv("ELASTICSTEPS_EXTRA_API_KEY", "secret")
+	t.Setenv("ELASTICSTEPS_NO_REFRESH_ADDRESSES", srv.URL)
+	t.Setenv("ELASTICSTEPS_NO_REFRESH_REFRESH", "false")
+
+	m, err := elasticsearch7.NewManagerFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"_default", "extra", "no-refresh"}, m.Instances())
+
+	runScenario(t, m, `
+    Given doc "41" is deleted from index "products"
+    And doc "42" is deleted from index "products" of es "extra"
+    And doc "43" is deleted from index "products" of es "no-refresh"
+`)
+
+	requests := srv.Requests()
+
+	require.Len(t, requests, 2)
+
+	assert.Equal(t, "/products/_doc/41", requests[0].URL.Path)
+	assert.Equal(t, "wait_for", requests[0].URL.Query().Get("refresh"))
+
+	assert.Equal(t, "/products/_doc/43", requests[1].URL.Path)
+	assert.Equal(t, "false", requests[1].URL.Query().Get("refresh"))
+
+	requests = extra.Requests()
+
+	require.Len(t, requests, 1)
+
+	assert.Equal(t, "APIKey secret", requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "true", requests[0].URL.Query().Get("refresh"))
+}
+
+func TestNewManagerFromEnv_InvalidRefresh(t *testing.T) { // nolint: paralleltest
+	t.Setenv("ELASTICSTEPS_REFRESH", "always")
+
+	_, err := elasticsearch7.NewManagerFromEnv()
+
+	assert.ErrorIs(t, err, elasticsearch7.ErrInvalidRefresh)
+}
@@ -1,21 +1,43 @@
 package elasticsearch7
 
+import (
+	"errors"
+
+	"github.com/godogx/elasticsteps"
+)
+
 const codeUnknown errCode = 0
 
+// ErrInvalidRefresh indicates that the refresh policy is not true, wait_for or false.
+var ErrInvalidRefresh = errors.New("invalid refresh policy")
+
+// ErrTaskTimeout indicates that a reindex or an update by query task is not completed in time.
+var ErrTaskTimeout = errors.New("task timeout")
+
 type errCode = int
 
-type err struct {
-	code    errCode
-	message string
+var _ elasticsteps.StatusError = (*Error)(nil)
+
+// Error is an error of a request to Elasticsearch.
+type Error struct {
+	// Code is the HTTP status code of the response, it is 0 if there is no response.
+	Code    errCode
+	Message string
+}
+
+// Error satisfies the error interface.
+func (e Error) Error() string {
+	return e.Message
 }
 
-func (e err) Error() string {
-	return e.message
+// StatusCode satisfies elasticsteps.StatusError.
+func (e Error) StatusCode() int {
+	return e.Code
 }
 
-func newError(code errCode, message string) *err {
-	return &err{
-		code:    code,
-		message: message,
+func newError(code errCode, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
 	}
 }
@@ -0,0 +1,102 @@
+package elasticsearch7
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/godogx/elasticsteps"
+)
+
+// PutLifecyclePolicy satisfies elasticsteps.LifecycleManager.
+func (c *Client) PutLifecyclePolicy(ctx context.Context, policy, body string) error {
+	put := c.es.ILM.PutLifecycle
+
+	if _, err := refineResp(put(policy, put.WithContext(ctx), put.WithBody(strings.NewReader(body)))); err != nil {
+		return ctxd.WrapError(ctx, err, "could not put lifecycle policy", "policy", policy)
+	}
+
+	return nil
+}
+
+// SetLifecyclePolicy satisfies elasticsteps.LifecycleManager.
+func (c *Client) SetLifecyclePolicy(ctx context.Context, index, policy string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"index.lifecycle.name": policy,
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not marshal lifecycle settings", "index", index, "policy", policy)
+	}
+
+	put := c.es.Indices.PutSettings
+
+	if _, err := refineResp(put(strings.NewReader(string(body)), put.WithContext(ctx), put.WithIndex(index))); err != nil {
+		return ctxd.WrapError(ctx, err, "could not set lifecycle policy", "index", index, "policy", policy)
+	}
+
+	return nil
+}
+
+// Rollover satisfies elasticsteps.LifecycleManager.
+func (c *Client) Rollover(ctx context.Context, alias string) error {
+	rollover := c.es.Indices.Rollover
+
+	resp, err := refineResp(rollover(alias, rollover.WithContext(ctx)))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not roll over alias", "alias", alias)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result struct {
+		RolledOver bool `json:"rolled_over"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ctxd.WrapError(ctx, err, "could not unmarshal rollover", "alias", alias)
+	}
+
+	if !result.RolledOver {
+		return ctxd.WrapError(ctx, fmt.Errorf("alias %q is not rolled over", alias), // nolint: goerr113
+			"could not roll over alias", "alias", alias)
+	}
+
+	return nil
+}
+
+// ExplainLifecycle satisfies elasticsteps.LifecycleManager.
+func (c *Client) ExplainLifecycle(ctx context.Context, index string) (elasticsteps.LifecycleState, error) {
+	explain := c.es.ILM.ExplainLifecycle
+
+	resp, err := refineResp(explain(index, explain.WithContext(ctx)))
+	if err != nil {
+		if err.Code == http.StatusNotFound {
+			return elasticsteps.LifecycleState{}, elasticsteps.ErrIndexNotFound
+		}
+
+		return elasticsteps.LifecycleState{}, ctxd.WrapError(ctx, err, "could not explain lifecycle", "index", index)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result struct {
+		Indices map[string]elasticsteps.LifecycleState `json:"indices"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return elasticsteps.LifecycleState{}, ctxd.WrapError(ctx, err, "could not unmarshal lifecycle", "index", index)
+	}
+
+	// With an alias, the states of all its indices are returned.
+	state, ok := result.Indices[index]
+	if !ok {
+		return elasticsteps.LifecycleState{}, ctxd.WrapError(ctx, fmt.Errorf("%d indices found, use the name of the index", len(result.Indices)), // nolint: goerr113
+			"could not explain lifecycle", "index", index)
+	}
+
+	return state, nil
+}
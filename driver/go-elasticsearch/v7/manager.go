@@ -8,10 +8,16 @@ import (
 
 // NewManager initiates a new data manager.
 func NewManager(client *es7.Client, opts ...elasticsteps.ManagerOption) *elasticsteps.Manager {
-	return elasticsteps.NewManager(wrapClient(client), opts...)
+	return elasticsteps.NewManager(NewClient(client), opts...)
 }
 
-// WithInstance adds a new es instance.
-func WithInstance(name string, client *es7.Client) elasticsteps.ManagerOption {
-	return elasticsteps.WithInstance(name, wrapClient(client))
+// NewManagerWithClientOptions initiates a new data manager, the client options set up the default instance, for
+// example WithRefresh().
+func NewManagerWithClientOptions(client *es7.Client, clientOpts []ClientOption, opts ...elasticsteps.ManagerOption) *elasticsteps.Manager {
+	return elasticsteps.NewManager(NewClient(client, clientOpts...), opts...)
+}
+
+// WithInstance adds a new es instance, the client options set it up, for example WithRefresh().
+func WithInstance(name string, client *es7.Client, opts ...ClientOption) elasticsteps.ManagerOption {
+	return elasticsteps.WithInstance(name, NewClient(client, opts...))
 }
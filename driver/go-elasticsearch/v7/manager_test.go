@@ -0,0 +1,118 @@
+package elasticsearch7_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cucumber/godog"
+	es7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/godogx/elasticsteps"
+	elasticsearch7 "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+)
+
+// requestRecorder is an es server that records the requests of the steps.
+type requestRecorder struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newRequestRecorder(t *testing.T) *requestRecorder {
+	t.Helper()
+
+	r := &requestRecorder{}
+
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+			return
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.requests = append(r.requests, req)
+
+		_, _ = w.Write([]byte(`{"result":"deleted"}`)) // nolint: errcheck
+	}))
+
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *requestRecorder) Requests() []*http.Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.requests
+}
+
+// runScenario runs the steps with the manager and fails the test if a step fails.
+func runScenario(t *testing.T, m *elasticsteps.Manager, steps string) {
+	t.Helper()
+
+	out := &bytes.Buffer{}
+	feature := "Feature: Driver\n\n  Scenario: Run steps\n" + steps
+
+	status := godog.TestSuite{
+		ScenarioInitializer: m.RegisterContext,
+		Options: &godog.Options{
+			Format:          "progress",
+			Output:          out,
+			Strict:          true,
+			FeatureContents: []godog.Feature{{Name: "driver.feature", Contents: []byte(feature)}},
+		},
+	}.Run()
+
+	require.Equal(t, 0, status, out.String())
+}
+
+func TestNewManagerWithClientOptions(t *testing.T) {
+	t.Parallel()
+
+	srv := newRequestRecorder(t)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	m := elasticsearch7.NewManagerWithClientOptions(es,
+		[]elasticsearch7.ClientOption{elasticsearch7.WithRefresh(elasticsearch7.RefreshWaitFor)},
+		elasticsearch7.WithInstance("norefresh", es, elasticsearch7.WithRefresh(elasticsearch7.RefreshFalse)),
+	)
+
+	runScenario(t, m, `
+    Given doc "41" is deleted from index "products"
+    And doc "42" is deleted from index "products" of es "norefresh"
+`)
+
+	requests := srv.Requests()
+
+	require.Len(t, requests, 2)
+
+	assert.Equal(t, "wait_for", requests[0].URL.Query().Get("refresh"))
+	assert.Equal(t, "false", requests[1].URL.Query().Get("refresh"))
+}
+
+func TestWithRefresh_Invalid(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithError(t, `invalid refresh policy: "yes", expected true, wait_for or false`, func() {
+		elasticsearch7.WithRefresh("yes")
+	})
+
+	_, err := elasticsearch7.ParseRefresh("yes")
+
+	assert.ErrorIs(t, err, elasticsearch7.ErrInvalidRefresh)
+}
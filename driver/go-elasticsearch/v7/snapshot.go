@@ -0,0 +1,123 @@
+package elasticsearch7
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bool64/ctxd"
+)
+
+// CreateSnapshotRepository satisfies elasticsteps.SnapshotManager.
+func (c *Client) CreateSnapshotRepository(ctx context.Context, repository, location string) error {
+	create := c.es.Snapshot.CreateRepository
+
+	body, err := json.Marshal(map[string]interface{}{
+		"type": "fs",
+		"settings": map[string]interface{}{
+			"location": location,
+		},
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not marshal snapshot repository", "repository", repository)
+	}
+
+	if _, err := refineResp(create(repository, bytes.NewReader(body), create.WithContext(ctx))); err != nil {
+		return ctxd.WrapError(ctx, err, "could not create snapshot repository", "repository", repository)
+	}
+
+	return nil
+}
+
+// CreateSnapshot satisfies elasticsteps.SnapshotManager.
+func (c *Client) CreateSnapshot(ctx context.Context, repository, snapshot string, indices ...string) error {
+	del := c.es.Snapshot.Delete
+
+	if _, err := refineResp(del(repository, snapshot, del.WithContext(ctx))); err != nil && err.Code != http.StatusNotFound {
+		return ctxd.WrapError(ctx, err, "could not delete snapshot", "repository", repository, "snapshot", snapshot)
+	}
+
+	create := c.es.Snapshot.Create
+
+	resp, err := refineResp(create(repository, snapshot,
+		create.WithContext(ctx),
+		create.WithBody(snapshotBody(indices)),
+		create.WithWaitForCompletion(true),
+	))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not create snapshot", "repository", repository, "snapshot", snapshot)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result struct {
+		Snapshot struct {
+			State string `json:"state"`
+		} `json:"snapshot"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ctxd.WrapError(ctx, err, "could not unmarshal snapshot", "repository", repository, "snapshot", snapshot)
+	}
+
+	if result.Snapshot.State != "SUCCESS" {
+		return ctxd.WrapError(ctx, fmt.Errorf("snapshot state is %s", result.Snapshot.State), // nolint: goerr113
+			"could not create snapshot", "repository", repository, "snapshot", snapshot)
+	}
+
+	return nil
+}
+
+// RestoreSnapshot satisfies elasticsteps.SnapshotManager. The target indices are deleted first.
+func (c *Client) RestoreSnapshot(ctx context.Context, repository, snapshot string, indices ...string) error {
+	// The open indices could not be restored, they are deleted first.
+	del := c.es.Indices.Delete
+
+	if _, err := refineResp(del(indices, del.WithContext(ctx), del.WithIgnoreUnavailable(true))); err != nil {
+		return ctxd.WrapError(ctx, err, "could not delete indices", "indices", indices)
+	}
+
+	restore := c.es.Snapshot.Restore
+
+	resp, err := refineResp(restore(repository, snapshot,
+		restore.WithContext(ctx),
+		restore.WithBody(snapshotBody(indices)),
+		restore.WithWaitForCompletion(true),
+	))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not restore snapshot", "repository", repository, "snapshot", snapshot)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var result struct {
+		Snapshot struct {
+			Shards struct {
+				Failed int `json:"failed"`
+			} `json:"shards"`
+		} `json:"snapshot"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ctxd.WrapError(ctx, err, "could not unmarshal restore", "repository", repository, "snapshot", snapshot)
+	}
+
+	if result.Snapshot.Shards.Failed > 0 {
+		return ctxd.WrapError(ctx, fmt.Errorf("%d shards failed", result.Snapshot.Shards.Failed), // nolint: goerr113
+			"could not restore snapshot", "repository", repository, "snapshot", snapshot)
+	}
+
+	return nil
+}
+
+func snapshotBody(indices []string) *strings.Reader {
+	body, _ := json.Marshal(map[string]interface{}{ // nolint: errcheck,errchkjson
+		"indices":              strings.Join(indices, ","),
+		"include_global_state": false,
+	})
+
+	return strings.NewReader(string(body))
+}
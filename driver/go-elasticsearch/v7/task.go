@@ -0,0 +1,219 @@
+package elasticsearch7
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+// taskPollInterval is the time between two checks of a running task.
+const taskPollInterval = 100 * time.Millisecond
+
+// taskCancelTimeout is the time to wait for the cancellation of a task that is not completed in time.
+const taskCancelTimeout = 10 * time.Second
+
+// DefaultTaskTimeout is the time to wait for a reindex or an update by query task to complete.
+const DefaultTaskTimeout = time.Minute
+
+// WithTaskTimeout sets the time to wait for a reindex or an update by query task to complete, default is
+// DefaultTaskTimeout.
+func WithTaskTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.taskTimeout = timeout
+	}
+}
+
+// Reindex satisfies elasticsteps.IndexReindexer.
+func (c *Client) Reindex(ctx context.Context, source, dest string, body *string) error {
+	req, err := reindexBody(source, dest, body)
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not read reindex body", "source", source, "dest", dest)
+	}
+
+	reindex := c.es.Reindex
+
+	resp, rErr := refineResp(reindex(strings.NewReader(req),
+		reindex.WithContext(ctx),
+		// The refresh of the destination happens when the task completes.
+		reindex.WithRefresh(c.refresh != RefreshFalse),
+		reindex.WithWaitForCompletion(false),
+	))
+	if rErr != nil {
+		return ctxd.WrapError(ctx, rErr, "could not reindex", "source", source, "dest", dest)
+	}
+
+	if err := c.waitForTask(ctx, resp); err != nil {
+		return ctxd.WrapError(ctx, err, "could not reindex", "source", source, "dest", dest)
+	}
+
+	return nil
+}
+
+// UpdateDocumentsByQuery satisfies elasticsteps.DocumentUpdater.
+func (c *Client) UpdateDocumentsByQuery(ctx context.Context, index string, body string) error {
+	updateByQuery := c.es.UpdateByQuery
+
+	resp, err := refineResp(updateByQuery([]string{index},
+		updateByQuery.WithContext(ctx),
+		updateByQuery.WithBody(strings.NewReader(body)),
+		// Update by query does not support wait_for.
+		updateByQuery.WithRefresh(c.refresh != RefreshFalse),
+		updateByQuery.WithWaitForCompletion(false),
+	))
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not update documents by query", "index", index)
+	}
+
+	if err := c.waitForTask(ctx, resp); err != nil {
+		return ctxd.WrapError(ctx, err, "could not update documents by query", "index", index)
+	}
+
+	return nil
+}
+
+// waitForTask polls the task started by the request until it completes or the task timeout expires, the failures of the
+// task are returned as an error. The task is cancelled when the step stops waiting for it.
+func (c *Client) waitForTask(ctx context.Context, resp *esapi.Response) error {
+	defer resp.Body.Close() // nolint: errcheck
+
+	var started struct {
+		Task string `json:"task"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&started); err != nil {
+		return fmt.Errorf("could not unmarshal task: %w", err)
+	}
+
+	timeout := time.NewTimer(c.taskTimeout)
+	defer timeout.Stop()
+
+	for {
+		status, err := c.getTask(ctx, started.Task)
+		if err != nil {
+			if ctx.Err() != nil {
+				return c.cancelTask(ctx, started.Task, err)
+			}
+
+			return err
+		}
+
+		if status.Completed {
+			return status.err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return c.cancelTask(ctx, started.Task, ctx.Err())
+
+		case <-timeout.C:
+			return c.cancelTask(ctx, started.Task,
+				fmt.Errorf("%w: task %q is not completed after %s", ErrTaskTimeout, started.Task, c.taskTimeout))
+
+		case <-time.After(taskPollInterval):
+		}
+	}
+}
+
+// cancelTask cancels a task that the step stops waiting for, so that it does not change the indices of the next
+// scenarios, and returns the cause with the error of the cancellation, if any.
+func (c *Client) cancelTask(ctx context.Context, id string, cause error) error {
+	// The context of the step could be done, only its values are kept for the request log and the tracing.
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, taskCancelTimeout)
+	defer cancel()
+
+	cancelTask := c.es.Tasks.Cancel
+
+	resp, err := refineResp(cancelTask(cancelTask.WithContext(ctx), cancelTask.WithTaskID(id)))
+	if err != nil {
+		return fmt.Errorf("%w, could not cancel task %q: %s", cause, id, err.Error())
+	}
+
+	_ = resp.Body.Close() // nolint: errcheck
+
+	return cause
+}
+
+// detachedContext keeps the values of a context without its deadline and cancellation.
+type detachedContext struct {
+	context.Context // nolint: containedctx
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+func (c *Client) getTask(ctx context.Context, id string) (*taskStatus, error) {
+	get := c.es.Tasks.Get
+
+	resp, err := refineResp(get(id, get.WithContext(ctx)))
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get task", "task", id)
+	}
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	var status taskStatus
+
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not unmarshal task", "task", id)
+	}
+
+	return &status, nil
+}
+
+type taskStatus struct {
+	Completed bool            `json:"completed"`
+	Error     json.RawMessage `json:"error"`
+	Response  struct {
+		Failures []json.RawMessage `json:"failures"`
+	} `json:"response"`
+}
+
+func (s taskStatus) err() error {
+	if len(s.Error) > 0 && string(s.Error) != "null" {
+		return newError(codeUnknown, string(s.Error))
+	}
+
+	if n := len(s.Response.Failures); n > 0 {
+		return newError(codeUnknown, fmt.Sprintf("%d failures, the first one is %s", n, s.Response.Failures[0]))
+	}
+
+	return nil
+}
+
+// reindexBody adds the source and the destination to the reindex request, the query of the request is moved into the
+// source.
+func reindexBody(source, dest string, body *string) (string, error) {
+	req := map[string]interface{}{}
+
+	if body != nil && strings.TrimSpace(*body) != "" {
+		if err := json.Unmarshal([]byte(*body), &req); err != nil {
+			return "", err
+		}
+	}
+
+	src := map[string]interface{}{"index": source}
+
+	if query, ok := req["query"]; ok {
+		src["query"] = query
+
+		delete(req, "query")
+	}
+
+	req["source"] = src
+	req["dest"] = map[string]interface{}{"index": dest}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,217 @@
+package elasticsearch7_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	es7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	elasticsearch7 "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+)
+
+func newTaskServer(t *testing.T, result string, body *string, cancelled *int32) *httptest.Server {
+	t.Helper()
+
+	var polls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+		case "/_reindex", "/products/_update_by_query":
+			b, _ := io.ReadAll(r.Body) // nolint: errcheck
+			*body = string(b)
+
+			assert.Equal(t, "false", r.URL.Query().Get("wait_for_completion"))
+
+			_, _ = w.Write([]byte(`{"task":"node:42"}`)) // nolint: errcheck
+
+		case "/_tasks/node:42/_cancel":
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			if cancelled == nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":{"type":"task_cancel_exception"},"status":500}`)) // nolint: errcheck
+
+				return
+			}
+
+			atomic.AddInt32(cancelled, 1)
+
+			_, _ = w.Write([]byte(`{"nodes":{}}`)) // nolint: errcheck
+
+		case "/_tasks/node:42":
+			// The task completes at the second poll.
+			if atomic.AddInt32(&polls, 1) < 2 {
+				_, _ = w.Write([]byte(`{"completed":false}`)) // nolint: errcheck
+
+				return
+			}
+
+			_, _ = w.Write([]byte(result)) // nolint: errcheck
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient_Reindex(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		body          *string
+		result        string
+		expectedBody  string
+		expectedError string
+	}{
+		{
+			scenario:     "without body",
+			result:       `{"completed":true,"response":{"created":2,"failures":[]}}`,
+			expectedBody: `{"dest":{"index":"products_v2"},"source":{"index":"products"}}`,
+		},
+		{
+			scenario:     "with query and script",
+			body:         stringPtr(`{"query":{"term":{"locale":"en_US"}},"script":{"source":"ctx._source.name += '!'"}}`),
+			result:       `{"completed":true,"response":{"created":1,"failures":[]}}`,
+			expectedBody: `{"dest":{"index":"products_v2"},"script":{"source":"ctx._source.name += '!'"},"source":{"index":"products","query":{"term":{"locale":"en_US"}}}}`,
+		},
+		{
+			scenario:      "invalid body",
+			body:          stringPtr(`{`),
+			expectedError: `could not read reindex body: unexpected end of JSON input`,
+		},
+		{
+			scenario:      "failures",
+			result:        `{"completed":true,"response":{"created":0,"failures":[{"id":"42","cause":{"type":"mapper_parsing_exception"}}]}}`,
+			expectedBody:  `{"dest":{"index":"products_v2"},"source":{"index":"products"}}`,
+			expectedError: `could not reindex: 1 failures, the first one is {"id":"42","cause":{"type":"mapper_parsing_exception"}}`,
+		},
+		{
+			scenario:      "error",
+			result:        `{"completed":true,"error":{"type":"index_not_found_exception"}}`,
+			expectedBody:  `{"dest":{"index":"products_v2"},"source":{"index":"products"}}`,
+			expectedError: `could not reindex: {"type":"index_not_found_exception"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			var body string
+
+			es, err := es7.NewClient(es7.Config{Addresses: []string{newTaskServer(t, tc.result, &body, new(int32)).URL}})
+			require.NoError(t, err)
+
+			err = elasticsearch7.NewClient(es).Reindex(context.Background(), "products", "products_v2", tc.body)
+
+			assert.Equal(t, tc.expectedBody, body)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_Reindex_Timeout(t *testing.T) {
+	t.Parallel()
+
+	var (
+		body      string
+		cancelled int32
+	)
+
+	// The task never completes.
+	es, err := es7.NewClient(es7.Config{Addresses: []string{newTaskServer(t, `{"completed":false}`, &body, &cancelled).URL}})
+	require.NoError(t, err)
+
+	c := elasticsearch7.NewClient(es, elasticsearch7.WithTaskTimeout(250*time.Millisecond))
+
+	err = c.Reindex(context.Background(), "products", "products_v2", nil)
+
+	assert.ErrorIs(t, err, elasticsearch7.ErrTaskTimeout)
+	assert.EqualError(t, err, `could not reindex: task timeout: task "node:42" is not completed after 250ms`)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled))
+}
+
+func TestClient_Reindex_Canceled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		body      string
+		cancelled int32
+	)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{newTaskServer(t, `{"completed":false}`, &body, &cancelled).URL}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	err = elasticsearch7.NewClient(es).Reindex(ctx, "products", "products_v2", nil)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled))
+}
+
+func TestClient_Reindex_CancelError(t *testing.T) {
+	t.Parallel()
+
+	var body string
+
+	// Without a counter, the cancel request fails.
+	srv := newTaskServer(t, `{"completed":false}`, &body, nil)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	c := elasticsearch7.NewClient(es, elasticsearch7.WithTaskTimeout(250*time.Millisecond))
+
+	err = c.Reindex(context.Background(), "products", "products_v2", nil)
+
+	assert.ErrorIs(t, err, elasticsearch7.ErrTaskTimeout)
+	assert.ErrorContains(t, err, `could not cancel task "node:42"`)
+}
+
+func TestClient_UpdateDocumentsByQuery(t *testing.T) {
+	t.Parallel()
+
+	var body string
+
+	update := `{"query":{"match_all":{}},"script":{"source":"ctx._source.locale = 'fr_FR'"}}`
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{
+		newTaskServer(t, `{"completed":true,"response":{"updated":2,"failures":[]}}`, &body, new(int32)).URL,
+	}})
+	require.NoError(t, err)
+
+	err = elasticsearch7.NewClient(es).UpdateDocumentsByQuery(context.Background(), "products", update)
+
+	assert.NoError(t, err)
+	assert.Equal(t, update, body)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,116 @@
+package elasticsearch7
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	es7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/godogx/elasticsteps"
+)
+
+const tracerName = "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+
+// transport traces the requests, and records them in the elasticsteps.RequestLog of their context.
+type transport struct {
+	next esapi.Transport
+}
+
+// instrument returns a client that performs the requests with the transport of the given client.
+func instrument(client *es7.Client) *es7.Client {
+	t := transport{next: client}
+
+	return &es7.Client{API: esapi.New(t), Transport: t}
+}
+
+func (t transport) Perform(req *http.Request) (*http.Response, error) {
+	// The spans are created only when the request is in a trace, for example when the manager has a tracer provider.
+	if parent := trace.SpanFromContext(req.Context()); parent.SpanContext().IsValid() {
+		ctx, span := parent.TracerProvider().Tracer(tracerName).Start(req.Context(), "elasticsearch: "+req.Method+" "+req.URL.Path,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemElasticsearch,
+				semconv.HTTPMethodKey.String(req.Method),
+				semconv.HTTPTargetKey.String(req.URL.RequestURI()),
+			),
+		)
+		defer span.End()
+
+		req = req.WithContext(ctx)
+
+		resp, err := t.record(req)
+
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+		default:
+			span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+		}
+
+		return resp, err
+	}
+
+	return t.record(req)
+}
+
+func (t transport) record(req *http.Request) (*http.Response, error) {
+	log := elasticsteps.RequestLogFromContext(req.Context())
+	if log == nil {
+		return t.next.Perform(req)
+	}
+
+	entry := elasticsteps.RequestLogEntry{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		entry.RequestBody = string(body)
+	}
+
+	start := time.Now()
+	resp, err := t.next.Perform(req)
+	entry.Duration = time.Since(start).Round(time.Millisecond)
+
+	switch {
+	case err != nil:
+		entry.Err = err
+
+	case resp.StatusCode >= http.StatusBadRequest:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close() // nolint: errcheck
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		entry.Status = resp.StatusCode
+		entry.ResponseBody = string(body)
+
+	default:
+		entry.Status = resp.StatusCode
+	}
+
+	log.Add(entry)
+
+	return resp, err
+}
@@ -0,0 +1,170 @@
+package elasticsearch7_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cucumber/godog"
+	es7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/godogx/elasticsteps"
+	elasticsearch7 "github.com/godogx/elasticsteps/driver/go-elasticsearch/v7"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"7.17.10","build_flavor":"default"},"tagline":"You Know, for Search"}`)) // nolint: errcheck
+
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"type":"resource_already_exists_exception"},"status":400}`)) // nolint: errcheck
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient_RequestLog(t *testing.T) {
+	t.Parallel()
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{newServer(t).URL}})
+	require.NoError(t, err)
+
+	c := elasticsearch7.NewClient(es)
+	config := `{"mappings":{}}`
+
+	// Without a log in the context, nothing is recorded.
+	assert.Error(t, c.CreateIndex(context.Background(), "products", &config))
+
+	l := &elasticsteps.RequestLog{}
+	ctx := elasticsteps.ContextWithRequestLog(context.Background(), l)
+
+	assert.Error(t, c.CreateIndex(ctx, "products", &config))
+
+	entries := l.Entries()
+
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, http.MethodPut, entries[0].Method)
+	assert.Equal(t, "/products", entries[0].URL)
+	assert.Equal(t, config, entries[0].RequestBody)
+	assert.Equal(t, http.StatusBadRequest, entries[0].Status)
+	assert.Equal(t, `{"error":{"type":"resource_already_exists_exception"},"status":400}`, entries[0].ResponseBody)
+}
+
+func TestClient_RequestLog_Bulk(t *testing.T) {
+	t.Parallel()
+
+	srv := newRequestRecorder(t)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	c := elasticsearch7.NewClient(es)
+
+	l := &elasticsteps.RequestLog{}
+	ctx := elasticsteps.ContextWithRequestLog(context.Background(), l)
+
+	require.NoError(t, c.IndexDocuments(ctx, "products", elasticsteps.Document{ID: "41", Source: []byte(`{"name":"foo"}`)}))
+
+	entries := l.Entries()
+
+	require.Len(t, entries, 1)
+
+	assert.Equal(t, http.MethodPost, entries[0].Method)
+	assert.Equal(t, "/products/_bulk?refresh=true", entries[0].URL)
+	assert.Equal(t, `{"index":{"_id":"41"}}
+{"name":"foo"}
+`, entries[0].RequestBody)
+	assert.Equal(t, http.StatusOK, entries[0].Status)
+}
+
+func TestManager_RequestLog_Bulk(t *testing.T) {
+	t.Parallel()
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{newRequestRecorder(t).URL}})
+	require.NoError(t, err)
+
+	log := &bytes.Buffer{}
+	m := elasticsteps.NewManager(elasticsearch7.NewClient(es), elasticsteps.WithRequestLog(log))
+
+	feature := `Feature: Driver
+
+  Scenario: Index docs
+    Given these docs are stored in index "products":
+    """
+    [{"_id": "41", "_source": {"name": "foo"}}]
+    """
+    Then index "products" exists in es "extra"
+`
+
+	status := godog.TestSuite{
+		ScenarioInitializer: m.RegisterContext,
+		Options: &godog.Options{
+			Format:          "progress",
+			Output:          &bytes.Buffer{},
+			Strict:          true,
+			FeatureContents: []godog.Feature{{Name: "driver.feature", Contents: []byte(feature)}},
+		},
+	}.Run()
+
+	require.Equal(t, 1, status)
+
+	assert.Contains(t, log.String(), "POST /products/_bulk?refresh=true 200")
+	assert.Contains(t, log.String(), `> {"index":{"_id":"41"}}`)
+}
+
+func TestClient_Tracing(t *testing.T) {
+	t.Parallel()
+
+	srv := newServer(t)
+
+	es, err := es7.NewClient(es7.Config{Addresses: []string{srv.URL}})
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c := elasticsearch7.NewClient(es)
+
+	// Without a span in the context, nothing is traced.
+	assert.Error(t, c.CreateIndex(context.Background(), "products", nil))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "step")
+
+	assert.Error(t, c.CreateIndex(ctx, "products", nil))
+
+	parent.End()
+
+	spans := recorder.Ended()
+
+	require.Len(t, spans, 2)
+
+	span := spans[0]
+
+	assert.Equal(t, "elasticsearch: PUT /products", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Contains(t, span.Attributes(), attribute.String("db.system", "elasticsearch"))
+	assert.Contains(t, span.Attributes(), attribute.String("http.method", http.MethodPut))
+	assert.Contains(t, span.Attributes(), attribute.String("http.target", "/products"))
+	assert.Contains(t, span.Attributes(), attribute.Int("http.status_code", http.StatusBadRequest))
+}
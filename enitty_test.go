@@ -52,6 +52,29 @@ func TestDocument_UnmarshalJSON(t *testing.T) {
 				Source: []byte(validCompactedSource),
 			},
 		},
+		{
+			scenario: "success with indexing options",
+			payload: `{
+	"_id": "41",
+	"_source": {"handle": "item-41"},
+	"_routing": "tenant-1",
+	"_version": 3,
+	"_version_type": "external",
+	"if_seq_no": 5,
+	"if_primary_term": 1,
+	"_op_type": "create"
+}`,
+			expectedResult: elasticsteps.Document{
+				ID:            "41",
+				Source:        []byte(`{"handle":"item-41"}`),
+				Routing:       "tenant-1",
+				Version:       int64Ptr(3),
+				VersionType:   "external",
+				IfSeqNo:       int64Ptr(5),
+				IfPrimaryTerm: int64Ptr(1),
+				OpType:        "create",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -133,3 +156,7 @@ func TestSearchResultHitsHit_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -6,10 +6,19 @@ import (
 )
 
 // Document represents an Elasticsearch doc.
+//
+// Besides the ID and the source, a document could carry the routing, the version, the sequence number, the primary
+// term and the operation type (index or create) that are used while indexing.
 // nolint: tagliatelle
 type Document struct {
-	ID     string          `json:"_id"`
-	Source json.RawMessage `json:"_source"`
+	ID            string          `json:"_id"`
+	Source        json.RawMessage `json:"_source"`
+	Routing       string          `json:"_routing,omitempty"`
+	Version       *int64          `json:"_version,omitempty"`
+	VersionType   string          `json:"_version_type,omitempty"`
+	IfSeqNo       *int64          `json:"if_seq_no,omitempty"`
+	IfPrimaryTerm *int64          `json:"if_primary_term,omitempty"`
+	OpType        string          `json:"_op_type,omitempty"`
 }
 
 type document Document
@@ -1,6 +1,63 @@
 package elasticsteps
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ErrIndexNotFound indicates that the index is not found.
 var ErrIndexNotFound = errors.New("index not found")
+
+// ErrInstanceNotFound indicates that the es instance is not registered.
+var ErrInstanceNotFound = errors.New("instance not found")
+
+// ErrNotSupported indicates that the client of the es instance does not implement the interface that a step needs.
+var ErrNotSupported = errors.New("not supported")
+
+// BulkError indicates that some documents could not be indexed.
+type BulkError struct {
+	Failures []BulkFailure
+}
+
+// BulkFailure represents a document that could not be indexed.
+// nolint: tagliatelle
+type BulkFailure struct {
+	ID     string `json:"_id"`
+	Status int    `json:"status"`
+	Type   string `json:"type,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Error satisfies the error interface.
+func (e *BulkError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("could not index all documents")
+
+	for i, f := range e.Failures {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+
+		_, _ = fmt.Fprintf(&sb, "doc %q: status %d", f.ID, f.Status)
+
+		if f.Type != "" {
+			_, _ = fmt.Fprintf(&sb, ", %s", f.Type)
+		}
+
+		if f.Reason != "" {
+			_, _ = fmt.Fprintf(&sb, ", %s", f.Reason)
+		}
+	}
+
+	return sb.String()
+}
+
+// StatusError is an error that carries the HTTP status code of the Elasticsearch response.
+type StatusError interface {
+	error
+	StatusCode() int
+}
@@ -0,0 +1,23 @@
+package elasticsteps_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/godogx/elasticsteps"
+)
+
+func TestBulkError_Error(t *testing.T) {
+	t.Parallel()
+
+	err := &elasticsteps.BulkError{Failures: []elasticsteps.BulkFailure{
+		{ID: "41", Status: 409, Type: "version_conflict_engine_exception"},
+		{ID: "42", Status: 400, Type: "mapper_parsing_exception", Reason: "failed to parse field [size]"},
+	}}
+
+	expected := `could not index all documents: doc "41": status 409, version_conflict_engine_exception; ` +
+		`doc "42": status 400, mapper_parsing_exception, failed to parse field [size]`
+
+	assert.EqualError(t, err, expected)
+}
@@ -0,0 +1,106 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cucumber/godog"
+	"github.com/swaggest/assertjson"
+)
+
+func (m *Manager) printExplanation(id, index, instance string) error {
+	s := m.storedSearch(index, instance)
+
+	return m.printSearchExplanation(id, index, instance, s)
+}
+
+func (m *Manager) printNamedSearchExplanation(id, name string) error {
+	s, ok := m.namedSearches[name]
+	if !ok {
+		return fmt.Errorf("search %q not found", name) // nolint: goerr113
+	}
+
+	return m.printSearchExplanation(id, s.index, s.instance, s)
+}
+
+func (m *Manager) printSearchExplanation(id, index, instance string, s *search) error {
+	explanation, err := m.explain(id, index, instance, s)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+
+	if err := json.Indent(&out, explanation, "", "    "); err != nil {
+		return fmt.Errorf("could not read explanation: %w", err)
+	}
+
+	_, err = fmt.Fprintf(m.output, "Explanation for doc %q in index %q:\n%s\n", id, index, out.String())
+
+	return err
+}
+
+func (m *Manager) assertExplanation(id, index, instance string, body *godog.DocString) error {
+	return m.compareExplanation(id, index, instance, body, assertjson.FailNotEqual)
+}
+
+func (m *Manager) assertExplanationMatches(id, index, instance string, body *godog.DocString) error {
+	return m.compareExplanation(id, index, instance, body, assertjson.FailMismatch)
+}
+
+func (m *Manager) compareExplanation(id, index, instance string, body *godog.DocString, compare func(expected, actual []byte) error) error {
+	s := m.storedSearch(index, instance)
+
+	explanation, err := m.explain(id, index, instance, s)
+	if err != nil {
+		return err
+	}
+
+	if err := compare([]byte(body.Content), explanation); err != nil {
+		return fmt.Errorf("failed to compare explanation: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manager) explain(id, index, instance string, s *search) (json.RawMessage, error) {
+	c, err := m.explainer(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := explainQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ExplainDocument(m.ctx(), index, id, query)
+}
+
+// explainQuery keeps only the query of the search because the explain api does not accept the other parts of the
+// search request, such as sort or aggs.
+func explainQuery(s *search) (string, error) {
+	if s != nil && s.template != nil {
+		return "", errors.New("could not explain a search by template") // nolint: goerr113
+	}
+
+	if s == nil || s.query == nil || len(*s.query) == 0 {
+		return `{"query":{"match_all":{}}}`, nil
+	}
+
+	var req struct {
+		Query json.RawMessage `json:"query"`
+	}
+
+	if err := json.Unmarshal([]byte(*s.query), &req); err != nil {
+		return "", fmt.Errorf("could not read search query: %w", err)
+	}
+
+	if req.Query == nil {
+		return `{"query":{"match_all":{}}}`, nil
+	}
+
+	return fmt.Sprintf(`{"query":%s}`, req.Query), nil
+}
@@ -0,0 +1,166 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_printExplanation(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"name":"red"}},"sort":["_score"]}`
+	explainQuery := `{"query":{"match":{"name":"red"}}}`
+
+	out := new(bytes.Buffer)
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &query).
+			Return(hitsResponse(), nil)
+
+		c.On("ExplainDocument", context.Background(), index, "42", explainQuery).
+			Return(`{"_id":"42","matched":true,"explanation":{"value":1.2}}`, nil)
+	})(t)
+
+	WithOutput(out)(m)
+
+	assert.NoError(t, m.findDocuments(index, instance, &godog.DocString{Content: query}))
+	assert.NoError(t, m.printExplanation("42", index, instance))
+
+	expected := `Explanation for doc "42" in index "test-index":
+{
+    "_id": "42",
+    "matched": true,
+    "explanation": {
+        "value": 1.2
+    }
+}
+`
+
+	assert.Equal(t, expected, out.String())
+}
+
+func TestManager_printNamedSearchExplanation(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, mock.Anything).
+			Return(hitsResponse(), nil)
+
+		c.On("ExplainDocument", context.Background(), index, "42", `{"query":{"match_all":{}}}`).
+			Return(nil, errors.New("explain error"))
+	})(t)
+
+	WithOutput(new(bytes.Buffer))(m)
+
+	assert.NoError(t, m.findDocumentsAs(index, instance, "all", &godog.DocString{Content: `{"size":10}`}))
+	assert.EqualError(t, m.printNamedSearchExplanation("42", "all"), `explain error`)
+	assert.EqualError(t, m.printNamedSearchExplanation("42", "unknown"), `search "unknown" not found`)
+}
+
+func TestManager_assertExplanation(t *testing.T) {
+	t.Parallel()
+
+	explanation := `{"_id":"42","matched":true,"explanation":{"value":1.2,"description":"weight(name:red)"}}`
+
+	testCases := []struct {
+		scenario      string
+		search        func(m *Manager) error
+		mock          managerMocker
+		assert        func(m *Manager, id, index, instance string, body *godog.DocString) error
+		expected      string
+		expectedError string
+	}{
+		{
+			scenario: "template",
+			search: func(m *Manager) error {
+				return m.findDocumentsWithTemplate(index, instance, &godog.DocString{Content: `{"id":"t"}`})
+			},
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocumentsByTemplate", context.Background(), index, `{"id":"t"}`).
+					Return(hitsResponse(), nil)
+			}),
+			assert:        (*Manager).assertExplanation,
+			expectedError: `could not explain a search by template`,
+		},
+		{
+			scenario: "invalid query",
+			search: func(m *Manager) error {
+				return m.findDocuments(index, instance, &godog.DocString{Content: `{`})
+			},
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, mock.Anything).
+					Return(hitsResponse(), nil)
+			}),
+			assert:        (*Manager).assertExplanation,
+			expectedError: `could not read search query: unexpected end of JSON input`,
+		},
+		{
+			scenario: "not equal",
+			search:   func(*Manager) error { return nil },
+			mock: mockManager(func(c *client) {
+				c.On("ExplainDocument", context.Background(), index, "42", `{"query":{"match_all":{}}}`).
+					Return(explanation, nil)
+			}),
+			assert:   (*Manager).assertExplanation,
+			expected: `{"_id":"42","matched":false,"explanation":"<ignore-diff>"}`,
+			expectedError: `failed to compare explanation: not equal:
+ {
+   "_id": "42",
+   "explanation": "<ignore-diff>",
+-  "matched": false
++  "matched": true
+ }
+`,
+		},
+		{
+			scenario: "equal",
+			search:   func(*Manager) error { return nil },
+			mock: mockManager(func(c *client) {
+				c.On("ExplainDocument", context.Background(), index, "42", `{"query":{"match_all":{}}}`).
+					Return(explanation, nil)
+			}),
+			assert:   (*Manager).assertExplanation,
+			expected: `{"_id":"42","matched":true,"explanation":{"value":"<ignore-diff>","description":"weight(name:red)"}}`,
+		},
+		{
+			scenario: "matches",
+			search: func(m *Manager) error {
+				return m.findDocuments(index, instance, &godog.DocString{Content: `{"query":{"match":{"name":"red"}}}`})
+			},
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, mock.Anything).
+					Return(hitsResponse(), nil)
+
+				c.On("ExplainDocument", context.Background(), index, "42", `{"query":{"match":{"name":"red"}}}`).
+					Return(explanation, nil)
+			}),
+			assert:   (*Manager).assertExplanationMatches,
+			expected: `{"matched":true}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.mock(t)
+
+			assert.NoError(t, tc.search(m))
+
+			err := tc.assert(m, "42", index, instance, &godog.DocString{Content: tc.expected})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -330,3 +330,909 @@ Feature: Test Different ElasticSearch driver
         """
 
         Then index "$DRIVER_default_index_14" exists
+
+    Scenario: Delete and update specific documents
+        Given there is index "$DRIVER_default_index_15"
+        And these docs are stored in index "$DRIVER_default_index_15":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            },
+            {
+                "_id": "42",
+                "_source": {
+                    "handle": "item-42",
+                    "name": "Item 42",
+                    "locale": "en_US"
+                }
+            },
+            {
+                "_id": "43",
+                "_source": {
+                    "handle": "item-43",
+                    "name": "Item 43",
+                    "locale": "fr_FR"
+                }
+            }
+        ]
+        """
+
+        When doc "41" is deleted from index "$DRIVER_default_index_15"
+        And docs matching query are deleted from index "$DRIVER_default_index_15":
+        """
+        {
+            "query": {
+                "match": {
+                    "locale": "fr_FR"
+                }
+            }
+        }
+        """
+        And doc "42" in index "$DRIVER_default_index_15" is updated with:
+        """
+        {
+            "doc": {
+                "name": "Item 42 (updated)"
+            }
+        }
+        """
+
+        Then only these docs are available in index "$DRIVER_default_index_15":
+        """
+        [
+            {
+                "_id": "42",
+                "_source": {
+                    "handle": "item-42",
+                    "name": "Item 42 (updated)",
+                    "locale": "en_US"
+                },
+                "_score": 1,
+                "_type": "_doc"
+            }
+        ]
+        """
+
+    Scenario: Index documents with custom routing and op type
+        Given there is index "$DRIVER_default_index_16"
+        And these docs are stored in index "$DRIVER_default_index_16":
+        """
+        [
+            {
+                "_id": "41",
+                "_routing": "tenant-1",
+                "_op_type": "create",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            },
+            {
+                "_id": "42",
+                "_version": 5,
+                "_version_type": "external",
+                "_source": {
+                    "handle": "item-42",
+                    "name": "Item 42",
+                    "locale": "en_US"
+                }
+            }
+        ]
+        """
+
+        Then only these docs are available in index "$DRIVER_default_index_16":
+        """
+        [
+            {
+                "_id": "41",
+                "_routing": "tenant-1",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                },
+                "_score": 1,
+                "_type": "_doc"
+            },
+            {
+                "_id": "42",
+                "_source": {
+                    "handle": "item-42",
+                    "name": "Item 42",
+                    "locale": "en_US"
+                },
+                "_score": 1,
+                "_type": "_doc"
+            }
+        ]
+        """
+
+    Scenario: Indexing documents with a mapping conflict fails
+        Given there is index "$DRIVER_default_index_17" with config:
+        """
+        {
+            "mappings": {
+                "properties": {
+                    "size": {
+                        "type": "integer"
+                    }
+                }
+            }
+        }
+        """
+
+        Then indexing these docs into index "$DRIVER_default_index_17" fails with:
+        """
+        {
+            "docs": [
+                {
+                    "_id": "41",
+                    "_source": {
+                        "size": 41
+                    }
+                },
+                {
+                    "_id": "42",
+                    "_source": {
+                        "size": "forty-two"
+                    }
+                }
+            ],
+            "failures": [
+                {
+                    "_id": "42",
+                    "status": 400,
+                    "type": "mapper_parsing_exception",
+                    "reason": "<ignore-diff>"
+                }
+            ]
+        }
+        """
+
+    Scenario: Refresh index explicitly
+        Given there is index "$DRIVER_default_index_18" in es "norefresh"
+        And these docs are stored in index "$DRIVER_default_index_18" of es "norefresh":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            }
+        ]
+        """
+
+        When index "$DRIVER_default_index_18" is refreshed in es "norefresh"
+
+        Then only these docs are available in index "$DRIVER_default_index_18" of es "norefresh":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                },
+                "_score": 1,
+                "_type": "_doc"
+            }
+        ]
+        """
+
+    Scenario: Creating index with invalid config fails
+        Given no index "$DRIVER_default_index_19"
+
+        Then creating index "$DRIVER_default_index_19" with config fails with status 400:
+        """
+        {
+            "mappings": {
+                "properties": {
+                    "size": {
+                        "type": "unknown"
+                    }
+                }
+            }
+        }
+        """
+        And creating index "$DRIVER_default_index_19" with config fails with error containing "mapper_parsing_exception":
+        """
+        {
+            "mappings": {
+                "properties": {
+                    "size": {
+                        "type": "unknown"
+                    }
+                }
+            }
+        }
+        """
+        And index "$DRIVER_default_index_19" does not exist
+
+        Given index "$DRIVER_default_index_19" is created
+
+        Then creating index "$DRIVER_default_index_19" fails with error containing "resource_already_exists_exception"
+
+    Scenario: Search for documents by query from a file
+        Given there is index "$DRIVER_default_index_20"
+        And docs in this file are stored in index "$DRIVER_default_index_20":
+        """
+        ../../resources/fixtures/products_mixed.json
+        """
+
+        When I search in index "$DRIVER_default_index_20" with query from file:
+        """
+        ../../resources/fixtures/query_en_us.json
+        """
+
+        Then docs in this file are found in index "$DRIVER_default_index_20":
+        """
+        ../../resources/fixtures/result_en_us.json
+        """
+
+    Scenario: Search for documents using a stored template
+        Given there is index "$DRIVER_default_index_21"
+        And docs in this file are stored in index "$DRIVER_default_index_21":
+        """
+        ../../resources/fixtures/products_mixed.json
+        """
+        And there is search template "$DRIVER_default_template_21" from file:
+        """
+        ../../resources/fixtures/template_locale.json
+        """
+
+        When I search in index "$DRIVER_default_index_21" using template "$DRIVER_default_template_21" with params:
+        """
+        {
+            "locale": "en_US"
+        }
+        """
+
+        Then docs in this file are found in index "$DRIVER_default_index_21":
+        """
+        ../../resources/fixtures/result_en_us.json
+        """
+
+    Scenario: Search for documents using an inline template
+        Given there is index "$DRIVER_default_index_22"
+        And docs in this file are stored in index "$DRIVER_default_index_22":
+        """
+        ../../resources/fixtures/products_mixed.json
+        """
+
+        When I search in index "$DRIVER_default_index_22" with template:
+        """
+        {
+            "source": {
+                "query": {
+                    "match": {
+                        "locale": "{{locale}}"
+                    }
+                }
+            },
+            "params": {
+                "locale": "en_US"
+            }
+        }
+        """
+
+        Then docs in this file are found in index "$DRIVER_default_index_22":
+        """
+        ../../resources/fixtures/result_en_us.json
+        """
+
+    Scenario: Check the whole search response
+        Given there is index "$DRIVER_default_index_23" with config:
+        """
+        {
+            "mappings": {
+                "properties": {
+                    "handle": {
+                        "type": "keyword"
+                    },
+                    "name": {
+                        "type": "text"
+                    },
+                    "locale": {
+                        "type": "keyword"
+                    }
+                }
+            }
+        }
+        """
+        And docs in this file are stored in index "$DRIVER_default_index_23":
+        """
+        ../../resources/fixtures/products_mixed.json
+        """
+
+        When I search in index "$DRIVER_default_index_23" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "name": "41"
+                }
+            },
+            "highlight": {
+                "fields": {
+                    "name": {}
+                }
+            },
+            "aggs": {
+                "locales": {
+                    "terms": {
+                        "field": "locale"
+                    }
+                }
+            }
+        }
+        """
+
+        Then the search response is:
+        """
+        {
+            "took": "<ignore-diff>",
+            "timed_out": false,
+            "_shards": "<ignore-diff>",
+            "hits": {
+                "total": {
+                    "value": 1,
+                    "relation": "eq"
+                },
+                "max_score": "<ignore-diff>",
+                "hits": [
+                    {
+                        "_index": "$DRIVER_default_index_23",
+                        "_type": "_doc",
+                        "_id": "41",
+                        "_score": "<ignore-diff>",
+                        "_source": {
+                            "handle": "item-41",
+                            "name": "Item 41",
+                            "locale": "en_US"
+                        },
+                        "highlight": {
+                            "name": ["Item <em>41</em>"]
+                        }
+                    }
+                ]
+            },
+            "aggregations": {
+                "locales": {
+                    "doc_count_error_upper_bound": 0,
+                    "sum_other_doc_count": 0,
+                    "buckets": [
+                        {
+                            "key": "en_US",
+                            "doc_count": 1
+                        }
+                    ]
+                }
+            }
+        }
+        """
+        And the search response matches:
+        """
+        {
+            "hits": {
+                "hits": [
+                    {
+                        "_id": "41",
+                        "highlight": {
+                            "name": ["Item <em>41</em>"]
+                        }
+                    }
+                ]
+            }
+        }
+        """
+
+    Scenario: Compare several named searches in the same index
+        Given there is index "$DRIVER_default_index_24"
+        And docs in this file are stored in index "$DRIVER_default_index_24":
+        """
+        ../../resources/fixtures/products_mixed.json
+        """
+
+        When I search in index "$DRIVER_default_index_24" as "en" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "locale": "en_US"
+                }
+            }
+        }
+        """
+        And I search in index "$DRIVER_default_index_24" as "fr" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "locale": "fr_FR"
+                }
+            }
+        }
+        """
+
+        Then docs in this file are found by search "en":
+        """
+        ../../resources/fixtures/result_en_us.json
+        """
+        And docs found by search "fr" are:
+        """
+        [
+            {
+                "_id": "43",
+                "_source": {
+                    "handle": "item-43",
+                    "name": "Item 43",
+                    "locale": "fr_FR"
+                },
+                "_score": "<ignore-diff>",
+                "_type": "_doc"
+            }
+        ]
+        """
+
+    Scenario: Check the order and the scores of the search results
+        Given there is index "$DRIVER_default_index_25"
+        And these docs are stored in index "$DRIVER_default_index_25":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "name": "red shoes"
+                }
+            },
+            {
+                "_id": "42",
+                "_source": {
+                    "name": "red red shoes"
+                }
+            },
+            {
+                "_id": "43",
+                "_source": {
+                    "name": "blue hat"
+                }
+            }
+        ]
+        """
+
+        When I search in index "$DRIVER_default_index_25" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "name": "red"
+                }
+            }
+        }
+        """
+
+        Then the search in index "$DRIVER_default_index_25" returns ids in order: 42, 41
+        And doc "42" scores higher than doc "41"
+        And max score is greater than 0
+
+        When I search in index "$DRIVER_default_index_25" as "red" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "name": "red"
+                }
+            }
+        }
+        """
+
+        And I search in index "$DRIVER_default_index_25" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "name": "hat"
+                }
+            }
+        }
+        """
+
+        Then doc "42" scores higher than doc "41" in search "red"
+        And max score of search "red" is greater than 0
+        And the search in index "$DRIVER_default_index_25" returns ids in order: 43
+
+    Scenario: Explain how a document matches the search
+        Given there is index "$DRIVER_default_index_26"
+        And these docs are stored in index "$DRIVER_default_index_26":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "name": "red shoes"
+                }
+            },
+            {
+                "_id": "42",
+                "_source": {
+                    "name": "blue hat"
+                }
+            }
+        ]
+        """
+
+        When I search in index "$DRIVER_default_index_26" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "name": "red"
+                }
+            },
+            "sort": ["_score"]
+        }
+        """
+        And the explanation for doc "41" in the search in index "$DRIVER_default_index_26" is printed
+
+        Then the explanation for doc "41" in the search in index "$DRIVER_default_index_26" matches:
+        """
+        {
+            "_id": "41",
+            "matched": true
+        }
+        """
+        And the explanation for doc "42" in the search in index "$DRIVER_default_index_26" is:
+        """
+        {
+            "_index": "$DRIVER_default_index_26",
+            "_type": "_doc",
+            "_id": "42",
+            "matched": false
+        }
+        """
+
+    Scenario: Analyze text with a custom analyzer
+        Given there is index "$DRIVER_default_index_27" with config:
+        """
+        {
+            "settings": {
+                "analysis": {
+                    "analyzer": {
+                        "folding": {
+                            "tokenizer": "standard",
+                            "filter": ["lowercase", "asciifolding"]
+                        }
+                    }
+                }
+            },
+            "mappings": {
+                "properties": {
+                    "name": {
+                        "type": "text",
+                        "analyzer": "folding"
+                    }
+                }
+            }
+        }
+        """
+
+        Then analyzing "Crème Brûlée" with analyzer "folding" in index "$DRIVER_default_index_27" produces tokens: creme, brulee
+        And analyzing "Crème Brûlée" with field "name" in index "$DRIVER_default_index_27" produces tokens: creme, brulee
+
+    Scenario: Search for documents across multiple indices
+        Given there is index "$DRIVER_default_index_28_en_us"
+        And there is index "$DRIVER_default_index_28_fr_fr"
+        And these docs are stored in index "$DRIVER_default_index_28_en_us":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            }
+        ]
+        """
+        And these docs are stored in index "$DRIVER_default_index_28_fr_fr":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Article 41",
+                    "locale": "fr_FR"
+                }
+            }
+        ]
+        """
+
+        When I search in index "$DRIVER_default_index_28_en_us,$DRIVER_default_index_28_fr_fr" with query:
+        """
+        {
+            "query": {
+                "match": {
+                    "handle": "item-41"
+                }
+            },
+            "sort": [
+                {"locale": {"order": "asc"}}
+            ]
+        }
+        """
+
+        Then these docs are found in index "$DRIVER_default_index_28_en_us,$DRIVER_default_index_28_fr_fr":
+        """
+        [
+            {
+                "_index": "$DRIVER_default_index_28_en_us",
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                },
+                "_score": "<ignore-diff>",
+                "_type": "_doc",
+                "sort": "<ignore-diff>"
+            },
+            {
+                "_index": "$DRIVER_default_index_28_fr_fr",
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Article 41",
+                    "locale": "fr_FR"
+                },
+                "_score": "<ignore-diff>",
+                "_type": "_doc",
+                "sort": "<ignore-diff>"
+            }
+        ]
+        """
+        And 2 docs are available in index "$DRIVER_default_index_28_*"
+        And 1 doc is available in index "$DRIVER_default_index_28_fr_fr"
+        # A pattern targets several indices, the hits keep their _index even when it matches a single index.
+        And only these docs are available in index "$DRIVER_default_index_28_fr_*":
+        """
+        [
+            {
+                "_index": "$DRIVER_default_index_28_fr_fr",
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Article 41",
+                    "locale": "fr_FR"
+                },
+                "_score": "<ignore-diff>",
+                "_type": "_doc"
+            }
+        ]
+        """
+
+    Scenario: Check the changes of an index since a snapshot
+        Given there is index "$DRIVER_default_index_29"
+        And docs in this file are stored in index "$DRIVER_default_index_29":
+        """
+        ../../resources/fixtures/products_mixed.json
+        """
+        And I take a snapshot of index "$DRIVER_default_index_29" as "before"
+
+        When doc "41" is deleted from index "$DRIVER_default_index_29"
+        And doc "43" in index "$DRIVER_default_index_29" is updated with:
+        """
+        {
+            "doc": {
+                "name": "Article 43"
+            }
+        }
+        """
+        And these docs are stored in index "$DRIVER_default_index_29":
+        """
+        [
+            {
+                "_id": "44",
+                "_source": {
+                    "handle": "item-44",
+                    "name": "Item 44",
+                    "locale": "en_US"
+                }
+            }
+        ]
+        """
+
+        Then index "$DRIVER_default_index_29" changed from snapshot "before" by:
+        """
+        {
+            "added": [
+                {
+                    "_id": "44",
+                    "_source": {
+                        "handle": "item-44",
+                        "name": "Item 44",
+                        "locale": "en_US"
+                    }
+                }
+            ],
+            "removed": ["41"],
+            "modified": [
+                {
+                    "_id": "43",
+                    "_source": {
+                        "handle": "item-43",
+                        "name": "Article 43",
+                        "locale": "fr_FR"
+                    }
+                }
+            ]
+        }
+        """
+
+    Scenario: Cluster is healthy
+        Given es is healthy
+        And es "extra" is healthy
+
+        When index "$DRIVER_default_index_30" is recreated
+
+        Then index "$DRIVER_default_index_30" exists
+
+    Scenario: Restore indices from a snapshot
+        Given no index "$DRIVER_default_index_31"
+        And index "$DRIVER_default_index_31" is created
+        And these docs are stored in index "$DRIVER_default_index_31":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            }
+        ]
+        """
+        And there is snapshot repository at "/tmp/elasticsteps/$DRIVER"
+        And there is snapshot "$DRIVER_seed" of indices "$DRIVER_default_index_31"
+        And index "$DRIVER_default_index_31" is recreated
+
+        When indices "$DRIVER_default_index_31" are restored from snapshot "$DRIVER_seed"
+
+        Then only these docs are available in index "$DRIVER_default_index_31":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            }
+        ]
+        """
+
+    Scenario: Reindex and update docs by query
+        Given no index "$DRIVER_default_index_32"
+        And no index "$DRIVER_default_index_33"
+        And index "$DRIVER_default_index_32" is created
+        And these docs are stored in index "$DRIVER_default_index_32":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41",
+                    "locale": "en_US"
+                }
+            },
+            {
+                "_id": "42",
+                "_source": {
+                    "handle": "item-42",
+                    "name": "Item 42",
+                    "locale": "fr_FR"
+                }
+            }
+        ]
+        """
+
+        When index "$DRIVER_default_index_32" is reindexed into index "$DRIVER_default_index_33" with:
+        """
+        {
+            "query": {
+                "term": {
+                    "locale.keyword": "en_US"
+                }
+            },
+            "script": {
+                "source": "ctx._source.name = ctx._source.name + params.suffix",
+                "params": {
+                    "suffix": " (migrated)"
+                }
+            }
+        }
+        """
+        And docs in index "$DRIVER_default_index_33" are updated by query:
+        """
+        {
+            "query": {
+                "match_all": {}
+            },
+            "script": {
+                "source": "ctx._source.locale = params.locale",
+                "params": {
+                    "locale": "en_GB"
+                }
+            }
+        }
+        """
+
+        Then only these docs are available in index "$DRIVER_default_index_33":
+        """
+        [
+            {
+                "_id": "41",
+                "_source": {
+                    "handle": "item-41",
+                    "name": "Item 41 (migrated)",
+                    "locale": "en_GB"
+                }
+            }
+        ]
+        """
+
+    Scenario: Roll over an index with a lifecycle policy
+        Given no index "$DRIVER_default_index_34-000001"
+        And no index "$DRIVER_default_index_34-000002"
+        And there is lifecycle policy "$DRIVER_default_policy_34" with config:
+        """
+        {
+            "policy": {
+                "phases": {
+                    "hot": {
+                        "actions": {
+                            "rollover": {
+                                "max_docs": 1000
+                            }
+                        }
+                    }
+                }
+            }
+        }
+        """
+        And index "$DRIVER_default_index_34-000001" is created with config:
+        """
+        {
+            "settings": {
+                "index.lifecycle.rollover_alias": "$DRIVER_default_index_34"
+            },
+            "aliases": {
+                "$DRIVER_default_index_34": {
+                    "is_write_index": true
+                }
+            }
+        }
+        """
+        And index "$DRIVER_default_index_34-000001" uses lifecycle policy "$DRIVER_default_policy_34"
+        And index "$DRIVER_default_index_34-000001" is in lifecycle step "check-rollover-ready"
+
+        When index alias "$DRIVER_default_index_34" is rolled over
+
+        Then index "$DRIVER_default_index_34-000002" exists
+        And index "$DRIVER_default_index_34-000001" is in lifecycle phase "hot"
+        And index "$DRIVER_default_index_34-000001" is in lifecycle step "complete"
@@ -1,8 +1,9 @@
 package bootstrap
 
 const (
-	esAddr  = "http://127.0.0.1:9200"
-	esExtra = "extra"
+	esAddr      = "http://127.0.0.1:9200"
+	esExtra     = "extra"
+	esNoRefresh = "norefresh"
 
 	typeES7 = "elasticsearch7"
 )
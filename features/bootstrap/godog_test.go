@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cucumber/godog"
 	"github.com/godogx/expandvars"
@@ -56,6 +58,9 @@ func TestIntegration(t *testing.T) {
 	for driver, manager := range drivers {
 		driver, manager := driver, manager
 
+		// The node could still be starting in the CI.
+		require.NoError(t, manager.WaitReady(context.Background(), time.Minute))
+
 		vars := expandvars.NewStepExpander(expandvars.Pairs{
 			"DRIVER": driver,
 		})
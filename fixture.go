@@ -0,0 +1,114 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// maxDumpDocs is the default max_result_window of an index.
+const maxDumpDocs = 10000
+
+// internalSettings are set by Elasticsearch and could not be used to create an index.
+var internalSettings = []string{"creation_date", "provided_name", "uuid", "version"}
+
+// DumpIndexConfig returns the mappings, the settings and the aliases of an index, in the format accepted by the steps
+// that create an index with config.
+func DumpIndexConfig(ctx context.Context, c IndexGetter, index string) (json.RawMessage, error) {
+	resp, err := c.GetIndex(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	var indices map[string]map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(resp))
+	dec.UseNumber()
+
+	if err := dec.Decode(&indices); err != nil {
+		return nil, fmt.Errorf("could not read index config: %w", err)
+	}
+
+	if len(indices) != 1 {
+		return nil, fmt.Errorf("expected 1 index for %q, got %d", index, len(indices)) // nolint: goerr113
+	}
+
+	var config map[string]interface{}
+
+	// The response is keyed by the concrete index, which could differ from the requested alias.
+	for _, v := range indices {
+		config = v
+	}
+
+	if aliases, ok := config["aliases"].(map[string]interface{}); ok && len(aliases) == 0 {
+		delete(config, "aliases")
+	}
+
+	if settings, ok := config["settings"].(map[string]interface{}); ok {
+		if settings, ok := settings["index"].(map[string]interface{}); ok {
+			for _, k := range internalSettings {
+				delete(settings, k)
+			}
+		}
+	}
+
+	return json.Marshal(config)
+}
+
+// DumpDocuments returns all the docs of an index, in the format accepted by the steps that store docs. Indices with
+// more than 10000 docs are not supported.
+func DumpDocuments(ctx context.Context, c DocumentSearcher, index string) ([]Document, error) {
+	hits, err := dumpHits(ctx, c, index)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]Document, len(hits))
+
+	for i, hit := range hits {
+		if err := json.Unmarshal(hit, &docs[i]); err != nil {
+			return nil, fmt.Errorf("could not read search response: %w", err)
+		}
+	}
+
+	return docs, nil
+}
+
+// dumpHits returns the hits of all the docs of an index, it fails instead of returning a part of the docs.
+func dumpHits(ctx context.Context, c DocumentSearcher, index string) ([]json.RawMessage, error) {
+	query := fmt.Sprintf(`{"query":{"match_all":{}},"size":%d,"track_total_hits":true,"sort":["_doc"]}`, maxDumpDocs)
+
+	resp, err := c.SearchDocuments(ctx, index, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hits struct {
+			Total SearchResultHitsTotal `json:"total"`
+			Hits  []json.RawMessage     `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("could not read search response: %w", err)
+	}
+
+	total := result.Hits.Total
+
+	if total.Value > maxDumpDocs {
+		return nil, fmt.Errorf("index %q has %d docs, more than %d docs are not supported", index, total.Value, maxDumpDocs) // nolint: goerr113
+	}
+
+	// The total is a lower bound when it is not tracked.
+	if len(result.Hits.Hits) < total.Value || total.Relation == "gte" {
+		return nil, fmt.Errorf("index %q has at least %d docs, only %d are returned", index, total.Value, len(result.Hits.Hits)) // nolint: goerr113
+	}
+
+	if result.Hits.Hits == nil {
+		return []json.RawMessage{}, nil
+	}
+
+	return result.Hits.Hits, nil
+}
@@ -0,0 +1,131 @@
+package elasticsteps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpIndexConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		mockClient     clientMocker
+		expectedResult string
+		expectedError  string
+	}{
+		{
+			scenario: "could not get index",
+			mockClient: mockClient(func(c *client) {
+				c.On("GetIndex", context.Background(), index).
+					Return(nil, ErrIndexNotFound)
+			}),
+			expectedError: `index not found`,
+		},
+		{
+			scenario: "more than one index",
+			mockClient: mockClient(func(c *client) {
+				c.On("GetIndex", context.Background(), index).
+					Return(`{"index-1":{},"index-2":{}}`, nil)
+			}),
+			expectedError: `expected 1 index for "test-index", got 2`,
+		},
+		{
+			scenario: "success",
+			mockClient: mockClient(func(c *client) {
+				c.On("GetIndex", context.Background(), index).
+					Return(`{"test-index-v1":{`+
+						`"aliases":{},`+
+						`"mappings":{"properties":{"price":{"type":"scaled_float","scaling_factor":100}}},`+
+						`"settings":{"index":{"number_of_shards":"1","provided_name":"test-index-v1","creation_date":"1","uuid":"abc","version":{"created":"7170099"}}}`+
+						`}}`, nil)
+			}),
+			expectedResult: `{
+				"mappings": {"properties": {"price": {"type": "scaled_float", "scaling_factor": 100}}},
+				"settings": {"index": {"number_of_shards": "1"}}
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := DumpIndexConfig(context.Background(), tc.mockClient(t), index)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.JSONEq(t, tc.expectedResult, string(result))
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestDumpDocuments(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match_all":{}},"size":10000,"track_total_hits":true,"sort":["_doc"]}`
+
+	testCases := []struct {
+		scenario       string
+		mockClient     clientMocker
+		expectedResult string
+		expectedError  string
+	}{
+		{
+			scenario: "could not search",
+			mockClient: mockClient(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(nil, errors.New("search error"))
+			}),
+			expectedError: `search error`,
+		},
+		{
+			scenario: "no docs",
+			mockClient: mockClient(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(`{"hits":{"total":{"value":0},"hits":[]}}`, nil)
+			}),
+			expectedResult: `[]`,
+		},
+		{
+			scenario: "success",
+			mockClient: mockClient(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(`{"hits":{"total":{"value":2},"hits":[`+
+						`{"_index":"test-index","_id":"41","_score":null,"_source":{"name": "Item 41"},"sort":[0]},`+
+						`{"_index":"test-index","_id":"42","_score":null,"_routing":"user-1","_source":{"name":"Item 42"},"sort":[1]}`+
+						`]}}`, nil)
+			}),
+			expectedResult: `[
+				{"_id": "41", "_source": {"name": "Item 41"}},
+				{"_id": "42", "_source": {"name": "Item 42"}, "_routing": "user-1"}
+			]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			docs, err := DumpDocuments(context.Background(), tc.mockClient(t), index)
+
+			if tc.expectedError == "" {
+				result, _ := json.Marshal(docs) // nolint: errcheck
+
+				assert.NoError(t, err)
+				assert.JSONEq(t, tc.expectedResult, string(result))
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
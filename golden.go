@@ -0,0 +1,176 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	envUpdateGoldenFiles = "ELASTICSTEPS_UPDATE_GOLDEN_FILES"
+
+	ignoreDiff = "<ignore-diff>"
+)
+
+func updateGoldenFilesFromEnv() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(envUpdateGoldenFiles)) // nolint: errcheck
+
+	return enabled
+}
+
+func (m *Manager) updateDocsFile(path, index, instance string, s *search) error {
+	docs, err := m.runSearch(index, instance, s)
+	if err != nil {
+		return err
+	}
+
+	if docs == nil {
+		docs = []json.RawMessage{}
+	}
+
+	return updateGoldenFile(path, docs)
+}
+
+// updateGoldenFile rewrites the file with the actual value, the <ignore-diff> placeholders of the file are kept.
+func updateGoldenFile(path string, actual interface{}) error {
+	data, err := json.Marshal(actual)
+	if err != nil {
+		return err
+	}
+
+	value, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path) // nolint: gosec
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not read golden file %q: %w", path, err)
+	}
+
+	// The file could be empty or invalid, in that case the placeholders are not kept.
+	if expected, err := decodeJSON(content); err == nil {
+		value = keepIgnoredDiffs(expected, value)
+	}
+
+	var buf bytes.Buffer
+
+	// Placeholders like <ignore-diff> must stay readable in the file.
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+
+	if err := enc.Encode(value); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("could not write golden file %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// decodeJSON keeps the numbers as they are, so the big ids or prices are not rounded.
+func decodeJSON(data []byte) (interface{}, error) {
+	var value interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// keepIgnoredDiffs copies the <ignore-diff> placeholders of the expected value into the actual one. The docs of an
+// array are matched by `_index` and `_id`, so the placeholders follow the docs when the order changes. The other
+// arrays are matched by position only when their lengths are equal.
+func keepIgnoredDiffs(expected, actual interface{}) interface{} {
+	if expected == ignoreDiff {
+		return ignoreDiff
+	}
+
+	switch exp := expected.(type) {
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+
+		for k, v := range act {
+			if e, ok := exp[k]; ok {
+				act[k] = keepIgnoredDiffs(e, v)
+			}
+		}
+
+	case []interface{}:
+		act, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+
+		if docs, ok := docsByID(exp); ok {
+			for i, v := range act {
+				if id, ok := docID(v); ok {
+					if e, ok := docs[id]; ok {
+						act[i] = keepIgnoredDiffs(e, v)
+					}
+				}
+			}
+
+			return actual
+		}
+
+		if len(act) != len(exp) {
+			return actual
+		}
+
+		for i := range act {
+			act[i] = keepIgnoredDiffs(exp[i], act[i])
+		}
+	}
+
+	return actual
+}
+
+// docsByID indexes the docs of an array by `_index` and `_id`, it fails when a value is not a doc.
+func docsByID(values []interface{}) (map[string]interface{}, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	docs := make(map[string]interface{}, len(values))
+
+	for _, v := range values {
+		id, ok := docID(v)
+		if !ok {
+			return nil, false
+		}
+
+		docs[id] = v
+	}
+
+	return docs, true
+}
+
+func docID(value interface{}) (string, bool) {
+	doc, ok := value.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	id, ok := doc["_id"].(string)
+	if !ok {
+		return "", false
+	}
+
+	index, _ := doc["_index"].(string) // nolint: errcheck
+
+	return index + "/" + id, true
+}
@@ -0,0 +1,138 @@
+package elasticsteps
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_assertAllDocsFromFile_UpdateGoldenFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "result.json")
+
+	err := os.WriteFile(path, []byte(`[{"_id":"41","_score":"<ignore-diff>","_source":{"name":"Item"}}]`), 0o600)
+	require.NoError(t, err)
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, (*string)(nil)).
+			Return(hitsResponse(
+				json.RawMessage(`{"_id":"41","_score":1.2,"_source":{"name":"Item 41","price":12345678901234567890}}`),
+				json.RawMessage(`{"_id":"42","_score":0.8,"_source":{"name":"Item 42","price":10}}`),
+			), nil)
+	})(t)
+
+	WithGoldenFilesUpdate(true)(m)
+
+	err = m.assertAllDocsFromFile(index, instance, &godog.DocString{Content: path})
+	require.NoError(t, err)
+
+	expected := `[
+    {
+        "_id": "41",
+        "_score": "<ignore-diff>",
+        "_source": {
+            "name": "Item 41",
+            "price": 12345678901234567890
+        }
+    },
+    {
+        "_id": "42",
+        "_score": 0.8,
+        "_source": {
+            "name": "Item 42",
+            "price": 10
+        }
+    }
+]
+`
+
+	actual, err := os.ReadFile(path) // nolint: gosec
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(actual))
+}
+
+func TestManager_assertFoundDocsFromFile_UpdateGoldenFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "result.json")
+	query := `{"query":{"match":{"locale":"fr_FR"}}}`
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &query).
+			Return(hitsResponse(), nil).Twice()
+	})(t)
+
+	WithGoldenFilesUpdate(true)(m)
+
+	require.NoError(t, m.findDocuments(index, instance, &godog.DocString{Content: query}))
+	require.NoError(t, m.assertFoundDocsFromFile(index, instance, &godog.DocString{Content: path}))
+
+	require.NoError(t, m.findDocumentsAs(index, instance, "fr", &godog.DocString{Content: query}))
+	require.NoError(t, m.assertNamedSearchDocsFromFile("fr", &godog.DocString{Content: path}))
+
+	actual, err := os.ReadFile(path) // nolint: gosec
+	require.NoError(t, err)
+
+	assert.Equal(t, "[]\n", string(actual))
+}
+
+func TestKeepIgnoredDiffs(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]interface{}{
+		"took":   ignoreDiff,
+		"hits":   []interface{}{ignoreDiff, "b"},
+		"tokens": []interface{}{ignoreDiff},
+		"aggs":   "not an object",
+	}
+
+	actual := map[string]interface{}{
+		"took":   3.0,
+		"hits":   []interface{}{"a", "b"},
+		"tokens": []interface{}{"a", "b"},
+		"aggs":   map[string]interface{}{"count": 1.0},
+		"new":    true,
+	}
+
+	result := keepIgnoredDiffs(expected, actual)
+
+	assert.Equal(t, map[string]interface{}{
+		"took": ignoreDiff,
+		"hits": []interface{}{ignoreDiff, "b"},
+		// The lengths differ, the placeholders could not be matched.
+		"tokens": []interface{}{"a", "b"},
+		"aggs":   map[string]interface{}{"count": 1.0},
+		"new":    true,
+	}, result)
+}
+
+func TestKeepIgnoredDiffs_ReorderedHits(t *testing.T) {
+	t.Parallel()
+
+	expected := []interface{}{
+		map[string]interface{}{"_id": "41", "_score": ignoreDiff, "_source": map[string]interface{}{"name": "Item 41"}},
+		map[string]interface{}{"_id": "42", "_score": 0.8, "_source": ignoreDiff},
+	}
+
+	actual := []interface{}{
+		map[string]interface{}{"_id": "43", "_score": 1.5, "_source": map[string]interface{}{"name": "Item 43"}},
+		map[string]interface{}{"_id": "42", "_score": 1.2, "_source": map[string]interface{}{"name": "Item 42"}},
+		map[string]interface{}{"_id": "41", "_score": 0.8, "_source": map[string]interface{}{"name": "Item 41"}},
+	}
+
+	result := keepIgnoredDiffs(expected, actual)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"_id": "43", "_score": 1.5, "_source": map[string]interface{}{"name": "Item 43"}},
+		map[string]interface{}{"_id": "42", "_score": 1.2, "_source": ignoreDiff},
+		map[string]interface{}{"_id": "41", "_score": ignoreDiff, "_source": map[string]interface{}{"name": "Item 41"}},
+	}, result)
+}
@@ -0,0 +1,82 @@
+package elasticsteps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cucumber/godog"
+)
+
+const (
+	// defaultReadyTimeout is the timeout of the `es is healthy` steps.
+	defaultReadyTimeout = 30 * time.Second
+	readyInterval       = 500 * time.Millisecond
+)
+
+// WaitReady waits until the health of all the es instances is yellow or green.
+func (m *Manager) WaitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, instance := range m.Instances() {
+		if err := m.waitReady(ctx, instance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TestSuiteInitializer waits for all the es instances before the suite starts. Use it as the
+// godog.TestSuite.TestSuiteInitializer. When the instances are not ready in time, all the scenarios fail with the error
+// and the suite returns a failed status.
+func (m *Manager) TestSuiteInitializer(timeout time.Duration) func(*godog.TestSuiteContext) {
+	return func(ctx *godog.TestSuiteContext) {
+		var err error
+
+		ctx.BeforeSuite(func() {
+			err = m.WaitReady(context.Background(), timeout)
+		})
+
+		// The hooks of the suite could not fail, the scenarios do.
+		ctx.ScenarioContext().Before(func(ctx context.Context, _ *godog.Scenario) (context.Context, error) {
+			return ctx, err
+		})
+	}
+}
+
+func (m *Manager) assertHealthy(instance string) error {
+	ctx, cancel := context.WithTimeout(m.ctx(), defaultReadyTimeout)
+	defer cancel()
+
+	return m.waitReady(ctx, instance)
+}
+
+func (m *Manager) waitReady(ctx context.Context, instance string) error {
+	c, err := m.healthChecker(instance)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(readyInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.ClusterHealth(ctx)
+		if err == nil {
+			if status == "green" || status == "yellow" {
+				return nil
+			}
+
+			err = fmt.Errorf("status %s", status) // nolint: goerr113
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s %q is not ready: %w", m.vocabulary.noun(), instance, err)
+
+		case <-ticker.C:
+		}
+	}
+}
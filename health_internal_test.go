@@ -0,0 +1,117 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_WaitReady(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mock          func(c *client)
+		expectedError string
+	}{
+		{
+			scenario: "green",
+			mock: func(c *client) {
+				c.On("ClusterHealth", mock.Anything).
+					Return("green", nil).Once()
+			},
+		},
+		{
+			scenario: "yellow after connection refused",
+			mock: func(c *client) {
+				c.On("ClusterHealth", mock.Anything).
+					Return("", errors.New("connection refused")).Once()
+
+				c.On("ClusterHealth", mock.Anything).
+					Return("yellow", nil).Once()
+			},
+		},
+		{
+			scenario: "red",
+			mock: func(c *client) {
+				c.On("ClusterHealth", mock.Anything).
+					Return("red", nil)
+			},
+			expectedError: `es "extra" is not ready: status red`,
+		},
+		{
+			scenario: "connection refused",
+			mock: func(c *client) {
+				c.On("ClusterHealth", mock.Anything).
+					Return("", errors.New("connection refused"))
+			},
+			expectedError: `es "extra" is not ready: connection refused`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewManager(
+				mockClient(func(c *client) {
+					c.On("ClusterHealth", mock.Anything).
+						Return("green", nil)
+				})(t),
+				WithInstance("extra", mockClient(tc.mock)(t)),
+			)
+
+			err := m.WaitReady(context.Background(), 800*time.Millisecond)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertHealthy_UnknownInstance(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+
+	assert.ErrorIs(t, m.assertHealthy("unknown"), ErrInstanceNotFound)
+}
+
+func TestManager_TestSuiteInitializer(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(mockClient(func(c *client) {
+		c.On("ClusterHealth", mock.Anything).
+			Return("red", nil)
+	})(t))
+
+	out := &bytes.Buffer{}
+
+	status := godog.TestSuite{
+		TestSuiteInitializer: m.TestSuiteInitializer(10 * time.Millisecond),
+		ScenarioInitializer:  m.RegisterContext,
+		Options: &godog.Options{
+			Format: "progress",
+			Output: out,
+			Strict: true,
+			FeatureContents: []godog.Feature{{Name: "health.feature", Contents: []byte(`Feature: Health
+
+  Scenario: Check an index
+    Then index "products" exists
+`)}},
+		},
+	}.Run()
+
+	assert.Equal(t, 1, status)
+	assert.Contains(t, out.String(), `es "_default" is not ready: status red`)
+}
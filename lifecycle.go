@@ -0,0 +1,100 @@
+package elasticsteps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cucumber/godog"
+)
+
+const (
+	// defaultLifecycleTimeout is the time the lifecycle assertions wait for, the policies run in the background.
+	defaultLifecycleTimeout = 30 * time.Second
+	lifecycleInterval       = 500 * time.Millisecond
+)
+
+func (m *Manager) putLifecyclePolicy(policy, instance string, body *godog.DocString) error {
+	c, err := m.lifecycleManager(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.PutLifecyclePolicy(m.ctx(), policy, body.Content)
+}
+
+func (m *Manager) setLifecyclePolicy(index, instance, policy string) error {
+	c, err := m.lifecycleManager(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.SetLifecyclePolicy(m.ctx(), index, policy)
+}
+
+func (m *Manager) rollover(alias, instance string) error {
+	c, err := m.lifecycleManager(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.Rollover(m.ctx(), alias)
+}
+
+func (m *Manager) assertLifecyclePhase(index, instance, phase string) error {
+	return m.waitLifecycle(index, instance, "phase", phase, func(s LifecycleState) string {
+		return s.Phase
+	})
+}
+
+func (m *Manager) assertLifecycleStep(index, instance, step string) error {
+	return m.waitLifecycle(index, instance, "step", step, func(s LifecycleState) string {
+		return s.Step
+	})
+}
+
+// waitLifecycle waits until the field of the lifecycle state of the index has the expected value.
+func (m *Manager) waitLifecycle(index, instance, field, expected string, value func(LifecycleState) string) error {
+	c, err := m.lifecycleManager(instance)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx(), m.lifecycleTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(lifecycleInterval)
+	defer ticker.Stop()
+
+	for {
+		state, err := c.ExplainLifecycle(ctx, index)
+		if err != nil {
+			return err
+		}
+
+		if value(state) == expected {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("index %q is not in lifecycle %s %q: %s", index, field, expected, describeLifecycle(state)) // nolint: goerr113
+
+		case <-ticker.C:
+		}
+	}
+}
+
+func describeLifecycle(s LifecycleState) string {
+	if !s.Managed {
+		return "the index is not managed by a lifecycle policy"
+	}
+
+	desc := fmt.Sprintf("policy %q, phase %q, action %q, step %q", s.Policy, s.Phase, s.Action, s.Step)
+
+	if s.FailedStep != "" {
+		desc += fmt.Sprintf(", failed step %q", s.FailedStep)
+	}
+
+	return desc
+}
@@ -0,0 +1,139 @@
+package elasticsteps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestManager_assertLifecyclePhase(t *testing.T) {
+	t.Parallel()
+
+	newIndex := LifecycleState{Managed: true, Policy: "logs", Phase: "new", Action: "complete", Step: "complete"}
+	hotIndex := LifecycleState{Managed: true, Policy: "logs", Phase: "hot", Action: "rollover", Step: "check-rollover-ready"}
+
+	testCases := []struct {
+		scenario      string
+		mock          func(c *client)
+		instance      string
+		phase         string
+		expectedError string
+	}{
+		{
+			scenario:      "unknown instance",
+			mock:          func(*client) {},
+			instance:      "extra",
+			phase:         "hot",
+			expectedError: `instance not found: es "extra", registered: _default`,
+		},
+		{
+			scenario: "index not found",
+			mock: func(c *client) {
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(LifecycleState{}, ErrIndexNotFound).Once()
+			},
+			instance:      instance,
+			phase:         "hot",
+			expectedError: `index not found`,
+		},
+		{
+			scenario: "in phase",
+			mock: func(c *client) {
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(hotIndex, nil).Once()
+			},
+			instance: instance,
+			phase:    "hot",
+		},
+		{
+			scenario: "in phase after a while",
+			mock: func(c *client) {
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(newIndex, nil).Once()
+
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(hotIndex, nil).Once()
+			},
+			instance: instance,
+			phase:    "hot",
+		},
+		{
+			scenario: "not in phase",
+			mock: func(c *client) {
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(newIndex, nil)
+			},
+			instance:      instance,
+			phase:         "hot",
+			expectedError: `index "test-index" is not in lifecycle phase "hot": policy "logs", phase "new", action "complete", step "complete"`,
+		},
+		{
+			scenario: "failed step",
+			mock: func(c *client) {
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(LifecycleState{Managed: true, Policy: "logs", Phase: "hot", Action: "rollover", Step: "ERROR", FailedStep: "check-rollover-ready"}, nil)
+			},
+			instance:      instance,
+			phase:         "warm",
+			expectedError: `index "test-index" is not in lifecycle phase "warm": policy "logs", phase "hot", action "rollover", step "ERROR", failed step "check-rollover-ready"`,
+		},
+		{
+			scenario: "not managed",
+			mock: func(c *client) {
+				c.On("ExplainLifecycle", mock.Anything, index).
+					Return(LifecycleState{}, nil)
+			},
+			instance:      instance,
+			phase:         "hot",
+			expectedError: `index "test-index" is not in lifecycle phase "hot": the index is not managed by a lifecycle policy`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := NewManager(mockClient(tc.mock)(t), WithLifecycleTimeout(800*time.Millisecond))
+
+			err := m.assertLifecyclePhase(index, tc.instance, tc.phase)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertLifecycleStep(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(mockClient(func(c *client) {
+		c.On("ExplainLifecycle", mock.Anything, index).
+			Return(LifecycleState{Managed: true, Policy: "logs", Phase: "hot", Action: "rollover", Step: "check-rollover-ready"}, nil)
+	})(t), WithLifecycleTimeout(0))
+
+	assert.NoError(t, m.assertLifecycleStep(index, instance, "check-rollover-ready"))
+
+	err := m.assertLifecycleStep(index, instance, "attempt-rollover")
+
+	assert.EqualError(t, err, `index "test-index" is not in lifecycle step "attempt-rollover": policy "logs", phase "hot", action "rollover", step "check-rollover-ready"`)
+}
+
+func TestManager_rollover(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(mockClient(func(c *client) {
+		c.On("Rollover", context.Background(), "logs").
+			Return(errors.New("rollover error"))
+	})(t))
+
+	assert.EqualError(t, m.rollover("logs", instance), `rollover error`)
+	assert.EqualError(t, m.rollover("logs", "extra"), `instance not found: es "extra", registered: _default`)
+}
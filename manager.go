@@ -5,148 +5,481 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/cucumber/godog"
 	"github.com/swaggest/assertjson"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const defaultInstance = "_default"
 
+// search is set up by the search actions and executed by the assertions.
+type search struct {
+	index    string
+	instance string
+	query    *string
+	template *string
+	// response is the response of the search step, the assertions check it instead of searching again.
+	response json.RawMessage
+}
+
 // Manager manages the elasticsearch data.
 type Manager struct {
-	instances map[string]Client
-	queries   map[string]map[string]*string
+	instances     map[string]Client
+	queries       map[string]map[string]*search
+	namedSearches map[string]*search
+	lastSearch    *search
+	snapshots     map[string]docSnapshot
+	output        io.Writer
+	vocabulary    vocabulary
+	requestLog    *RequestLog
+	stepCtx       context.Context // nolint: containedctx
+
+	defaultInstanceName string
+	lifecycleTimeout    time.Duration
+	requestLogOutput    io.Writer
+	tracerProvider      trace.TracerProvider
+	updateGoldenFiles   bool
 }
 
 // nolint: ireturn
-func (m *Manager) client(instance string) Client {
-	return m.instances[instance]
+func (m *Manager) client(instance string) (Client, error) {
+	c, ok := m.instances[m.instanceName(instance)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %q, registered: %s", ErrInstanceNotFound, m.vocabulary.noun(), instance, strings.Join(m.Instances(), ", "))
+	}
+
+	return c, nil
+}
+
+// instanceName returns the name the instance is registered with, the default instance could also be used with the name
+// of WithDefaultInstanceName().
+func (m *Manager) instanceName(instance string) string {
+	if instance == m.defaultInstanceName {
+		return defaultInstance
+	}
+
+	return instance
+}
+
+// Instances returns the sorted names of the registered es instances, including the default one.
+func (m *Manager) Instances() []string {
+	names := make([]string, 0, len(m.instances))
+
+	for name := range m.instances {
+		if name == defaultInstance {
+			name = m.defaultInstanceName
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
 }
 
 // nolint: funlen
-func (m *Manager) registerPrerequisites(sc *godog.ScenarioContext) {
-	sc.Step(`index "([^"]*)" is created in es "([^"]*)"$`, m.createIndex)
-	sc.Step(`index "([^"]*)" is created$`, func(index string) error {
+func (m *Manager) registerPrerequisites(sc stepRegistrar) {
+	sc.Step(`es "([^"]*)" is healthy$`, stepArgs{"instance"}, m.assertHealthy)
+	sc.Step(`es is healthy$`, nil, func() error {
+		return m.assertHealthy(defaultInstance)
+	})
+
+	sc.Step(`index "([^"]*)" is created in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.createIndex)
+	sc.Step(`index "([^"]*)" is created$`, stepArgs{"index"}, func(index string) error {
 		return m.createIndex(index, defaultInstance)
 	})
 
-	sc.Step(`index "([^"]*)" is created in es "([^"]*)" with config[:]?$`, m.createIndexWithConfig)
-	sc.Step(`index "([^"]*)" is created with config[:]?$`, func(index string, config *godog.DocString) error {
+	sc.Step(`index "([^"]*)" is created in es "([^"]*)" with config[:]?$`, stepArgs{"index", "instance"}, m.createIndexWithConfig)
+	sc.Step(`index "([^"]*)" is created with config[:]?$`, stepArgs{"index"}, func(index string, config *godog.DocString) error {
 		return m.createIndexWithConfig(index, defaultInstance, config)
 	})
 
-	sc.Step(`index "([^"]*)" is created in es "([^"]*)" with config from file[:]?$`, m.createIndexWithConfigFromFile)
-	sc.Step(`index "([^"]*)" is created with config from file[:]?$`, func(index string, body *godog.DocString) error {
+	sc.Step(`index "([^"]*)" is created in es "([^"]*)" with config from file[:]?$`, stepArgs{"index", "instance"}, m.createIndexWithConfigFromFile)
+	sc.Step(`index "([^"]*)" is created with config from file[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
 		return m.createIndexWithConfigFromFile(index, defaultInstance, body)
 	})
 
-	sc.Step(`index "([^"]*)" is recreated in es "([^"]*)"$`, m.recreateIndex)
-	sc.Step(`index "([^"]*)" is recreated$`, func(index string) error {
+	sc.Step(`index "([^"]*)" is recreated in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.recreateIndex)
+	sc.Step(`index "([^"]*)" is recreated$`, stepArgs{"index"}, func(index string) error {
 		return m.recreateIndex(index, defaultInstance)
 	})
 
-	sc.Step(`index "([^"]*)" is recreated in es "([^"]*)" with config[:]?$`, m.recreateIndexWithConfig)
-	sc.Step(`index "([^"]*)" is recreated with config[:]?$`, func(index string, config *godog.DocString) error {
+	sc.Step(`index "([^"]*)" is recreated in es "([^"]*)" with config[:]?$`, stepArgs{"index", "instance"}, m.recreateIndexWithConfig)
+	sc.Step(`index "([^"]*)" is recreated with config[:]?$`, stepArgs{"index"}, func(index string, config *godog.DocString) error {
 		return m.recreateIndexWithConfig(index, defaultInstance, config)
 	})
 
-	sc.Step(`index "([^"]*)" is recreated in es "([^"]*)" with config from file[:]?$`, m.recreateIndexWithConfigFromFile)
-	sc.Step(`index "([^"]*)" is recreated with config from file[:]?$`, func(index string, body *godog.DocString) error {
+	sc.Step(`index "([^"]*)" is recreated in es "([^"]*)" with config from file[:]?$`, stepArgs{"index", "instance"}, m.recreateIndexWithConfigFromFile)
+	sc.Step(`index "([^"]*)" is recreated with config from file[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
 		return m.recreateIndexWithConfigFromFile(index, defaultInstance, body)
 	})
 
-	sc.Step(`there is (?:an )?index "([^"]*)" in es "([^"]*)"$`, m.recreateIndex)
-	sc.Step(`there is (?:an )?index "([^"]*)"$`, func(index string) error {
+	sc.Step(`there is (?:an )?index "([^"]*)" in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.recreateIndex)
+	sc.Step(`there is (?:an )?index "([^"]*)"$`, stepArgs{"index"}, func(index string) error {
 		return m.recreateIndex(index, defaultInstance)
 	})
 
-	sc.Step(`there is (?:an )?index "([^"]*)" in es "([^"]*)" with config[:]?$`, m.recreateIndexWithConfig)
-	sc.Step(`there is (?:an )?index "([^"]*)" with config[:]?$`, func(index string, config *godog.DocString) error {
+	sc.Step(`there is (?:an )?index "([^"]*)" in es "([^"]*)" with config[:]?$`, stepArgs{"index", "instance"}, m.recreateIndexWithConfig)
+	sc.Step(`there is (?:an )?index "([^"]*)" with config[:]?$`, stepArgs{"index"}, func(index string, config *godog.DocString) error {
 		return m.recreateIndexWithConfig(index, defaultInstance, config)
 	})
 
-	sc.Step(`there is (?:an )?index "([^"]*)" in es "([^"]*)" with config from file[:]?$`, m.recreateIndexWithConfigFromFile)
-	sc.Step(`there is (?:an )?index "([^"]*)" with config from file[:]?$`, func(index string, body *godog.DocString) error {
+	sc.Step(`there is (?:an )?index "([^"]*)" in es "([^"]*)" with config from file[:]?$`, stepArgs{"index", "instance"}, m.recreateIndexWithConfigFromFile)
+	sc.Step(`there is (?:an )?index "([^"]*)" with config from file[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
 		return m.recreateIndexWithConfigFromFile(index, defaultInstance, body)
 	})
 
-	sc.Step(`no index "([^"]*)" in es "([^"]*)"$`, m.deleteIndex)
-	sc.Step(`no index "([^"]*)"$`, func(index string) error {
+	sc.Step(`no index "([^"]*)" in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.deleteIndex)
+	sc.Step(`no index "([^"]*)"$`, stepArgs{"index"}, func(index string) error {
 		return m.deleteIndex(index, defaultInstance)
 	})
 
-	sc.Step(`no docs in index "([^"]*)" of es "([^"]*)"$`, m.truncateIndex)
-	sc.Step(`no docs in index "([^"]*)"$`, func(index string) error {
+	sc.Step(`no docs in index "([^"]*)" of es "([^"]*)"$`, stepArgs{"index", "instance"}, m.truncateIndex)
+	sc.Step(`no docs in index "([^"]*)"$`, stepArgs{"index"}, func(index string) error {
 		return m.truncateIndex(index, defaultInstance)
 	})
 
-	sc.Step(`these docs are stored in index "([^"]*)" of es "([^"]*)"[:]?$`, m.indexDocs)
-	sc.Step(`these docs are stored in index "([^"]*)"[:]?$`, func(index string, docs *godog.DocString) error {
+	sc.Step(`doc "([^"]*)" is deleted from index "([^"]*)" of es "([^"]*)"$`, stepArgs{"id", "index", "instance"}, m.deleteDoc)
+	sc.Step(`doc "([^"]*)" is deleted from index "([^"]*)"$`, stepArgs{"id", "index"}, func(id, index string) error {
+		return m.deleteDoc(id, index, defaultInstance)
+	})
+
+	sc.Step(`docs matching query are deleted from index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.deleteDocsByQuery)
+	sc.Step(`docs matching query are deleted from index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, query *godog.DocString) error {
+		return m.deleteDocsByQuery(index, defaultInstance, query)
+	})
+
+	sc.Step(`doc "([^"]*)" in index "([^"]*)" of es "([^"]*)" is updated with[:]?$`, stepArgs{"id", "index", "instance"}, m.updateDoc)
+	sc.Step(`doc "([^"]*)" in index "([^"]*)" is updated with[:]?$`, stepArgs{"id", "index"}, func(id, index string, body *godog.DocString) error {
+		return m.updateDoc(id, index, defaultInstance, body)
+	})
+
+	sc.Step(`there is search template "([^"]*)" in es "([^"]*)"[:]?$`, stepArgs{"template", "instance"}, m.storeSearchTemplate)
+	sc.Step(`there is search template "([^"]*)"[:]?$`, stepArgs{"template"}, func(id string, body *godog.DocString) error {
+		return m.storeSearchTemplate(id, defaultInstance, body)
+	})
+
+	sc.Step(`there is search template "([^"]*)" in es "([^"]*)" from file[:]?$`, stepArgs{"template", "instance"}, m.storeSearchTemplateFromFile)
+	sc.Step(`there is search template "([^"]*)" from file[:]?$`, stepArgs{"template"}, func(id string, body *godog.DocString) error {
+		return m.storeSearchTemplateFromFile(id, defaultInstance, body)
+	})
+
+	sc.Step(`these docs are stored in index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.indexDocs)
+	sc.Step(`these docs are stored in index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, docs *godog.DocString) error {
 		return m.indexDocs(index, defaultInstance, docs)
 	})
 
-	sc.Step(`docs (?:in|from) this file are stored in index "([^"]*)" of es "([^"]*)"[:]?$`, m.indexDocsFromFile)
-	sc.Step(`docs (?:in|from) this file are stored in index "([^"]*)"[:]?$`, func(index string, body *godog.DocString) error {
+	sc.Step(`docs (?:in|from) this file are stored in index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.indexDocsFromFile)
+	sc.Step(`docs (?:in|from) this file are stored in index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
 		return m.indexDocsFromFile(index, defaultInstance, body)
 	})
+
+	sc.Step(`there is lifecycle policy "([^"]*)" in es "([^"]*)" with config[:]?$`, stepArgs{"policy", "instance"}, m.putLifecyclePolicy)
+	sc.Step(`there is lifecycle policy "([^"]*)" with config[:]?$`, stepArgs{"policy"}, func(policy string, body *godog.DocString) error {
+		return m.putLifecyclePolicy(policy, defaultInstance, body)
+	})
+
+	sc.Step(`index "([^"]*)" of es "([^"]*)" uses lifecycle policy "([^"]*)"$`, stepArgs{"index", "instance", "policy"}, m.setLifecyclePolicy)
+	sc.Step(`index "([^"]*)" uses lifecycle policy "([^"]*)"$`, stepArgs{"index", "policy"}, func(index, policy string) error {
+		return m.setLifecyclePolicy(index, defaultInstance, policy)
+	})
+
+	m.registerSnapshotPrerequisites(sc)
+}
+
+// nolint: funlen
+func (m *Manager) registerSnapshotPrerequisites(sc stepRegistrar) {
+	sc.Step(`there is snapshot repository "([^"]*)" at "([^"]*)" in es "([^"]*)"$`, stepArgs{"repository", "location", "instance"}, m.createSnapshotRepository)
+	sc.Step(`there is snapshot repository "([^"]*)" at "([^"]*)"$`, stepArgs{"repository", "location"}, func(repository, location string) error {
+		return m.createSnapshotRepository(repository, location, defaultInstance)
+	})
+
+	sc.Step(`there is snapshot repository at "([^"]*)" in es "([^"]*)"$`, stepArgs{"location", "instance"}, func(location, instance string) error {
+		return m.createSnapshotRepository(DefaultSnapshotRepository, location, instance)
+	})
+	sc.Step(`there is snapshot repository at "([^"]*)"$`, stepArgs{"location"}, func(location string) error {
+		return m.createSnapshotRepository(DefaultSnapshotRepository, location, defaultInstance)
+	})
+
+	sc.Step(`there is snapshot "([^"]*)" of indices "([^"]*)" in repository "([^"]*)" of es "([^"]*)"$`, stepArgs{"snapshot", "indices", "repository", "instance"}, m.createSnapshot)
+	sc.Step(`there is snapshot "([^"]*)" of indices "([^"]*)" in repository "([^"]*)"$`, stepArgs{"snapshot", "indices", "repository"}, func(snapshot, indices, repository string) error {
+		return m.createSnapshot(snapshot, indices, repository, defaultInstance)
+	})
+
+	sc.Step(`there is snapshot "([^"]*)" of indices "([^"]*)" in es "([^"]*)"$`, stepArgs{"snapshot", "indices", "instance"}, func(snapshot, indices, instance string) error {
+		return m.createSnapshot(snapshot, indices, DefaultSnapshotRepository, instance)
+	})
+	sc.Step(`there is snapshot "([^"]*)" of indices "([^"]*)"$`, stepArgs{"snapshot", "indices"}, func(snapshot, indices string) error {
+		return m.createSnapshot(snapshot, indices, DefaultSnapshotRepository, defaultInstance)
+	})
+
+	sc.Step(`indices "([^"]*)" are restored from snapshot "([^"]*)" in repository "([^"]*)" of es "([^"]*)"$`, stepArgs{"indices", "snapshot", "repository", "instance"}, m.restoreSnapshot)
+	sc.Step(`indices "([^"]*)" are restored from snapshot "([^"]*)" in repository "([^"]*)"$`, stepArgs{"indices", "snapshot", "repository"}, func(indices, snapshot, repository string) error {
+		return m.restoreSnapshot(indices, snapshot, repository, defaultInstance)
+	})
+
+	sc.Step(`indices "([^"]*)" are restored from snapshot "([^"]*)" in es "([^"]*)"$`, stepArgs{"indices", "snapshot", "instance"}, func(indices, snapshot, instance string) error {
+		return m.restoreSnapshot(indices, snapshot, DefaultSnapshotRepository, instance)
+	})
+	sc.Step(`indices "([^"]*)" are restored from snapshot "([^"]*)"$`, stepArgs{"indices", "snapshot"}, func(indices, snapshot string) error {
+		return m.restoreSnapshot(indices, snapshot, DefaultSnapshotRepository, defaultInstance)
+	})
 }
 
-func (m *Manager) registerActions(sc *godog.ScenarioContext) {
-	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" with query[:]?$`, m.findDocuments)
-	sc.Step(`I search in index "([^"]*)" with query[:]?$`, func(index string, query *godog.DocString) error {
+// nolint: funlen
+func (m *Manager) registerActions(sc stepRegistrar) {
+	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" with query[:]?$`, stepArgs{"index", "instance"}, m.findDocuments)
+	sc.Step(`I search in index "([^"]*)" with query[:]?$`, stepArgs{"index"}, func(index string, query *godog.DocString) error {
 		return m.findDocuments(index, defaultInstance, query)
 	})
+
+	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" as "([^"]*)" with query[:]?$`, stepArgs{"index", "instance", "name"}, m.findDocumentsAs)
+	sc.Step(`I search in index "([^"]*)" as "([^"]*)" with query[:]?$`, stepArgs{"index", "name"}, func(index, name string, query *godog.DocString) error {
+		return m.findDocumentsAs(index, defaultInstance, name, query)
+	})
+
+	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" with query from file[:]?$`, stepArgs{"index", "instance"}, m.findDocumentsWithQueryFromFile)
+	sc.Step(`I search in index "([^"]*)" with query from file[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
+		return m.findDocumentsWithQueryFromFile(index, defaultInstance, body)
+	})
+
+	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" with template[:]?$`, stepArgs{"index", "instance"}, m.findDocumentsWithTemplate)
+	sc.Step(`I search in index "([^"]*)" with template[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
+		return m.findDocumentsWithTemplate(index, defaultInstance, body)
+	})
+
+	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" using template "([^"]*)"$`, stepArgs{"index", "instance", "template"}, func(index, instance, id string) error {
+		return m.findDocumentsWithStoredTemplate(index, instance, id, nil)
+	})
+	sc.Step(`I search in index "([^"]*)" using template "([^"]*)"$`, stepArgs{"index", "template"}, func(index, id string) error {
+		return m.findDocumentsWithStoredTemplate(index, defaultInstance, id, nil)
+	})
+
+	sc.Step(`I search in index "([^"]*)" of es "([^"]*)" using template "([^"]*)" with params[:]?$`, stepArgs{"index", "instance", "template"}, m.findDocumentsWithStoredTemplate)
+	sc.Step(`I search in index "([^"]*)" using template "([^"]*)" with params[:]?$`, stepArgs{"index", "template"}, func(index, id string, params *godog.DocString) error {
+		return m.findDocumentsWithStoredTemplate(index, defaultInstance, id, params)
+	})
+
+	sc.Step(`I take a snapshot of index "([^"]*)" of es "([^"]*)" as "([^"]*)"$`, stepArgs{"index", "instance", "name"}, m.takeSnapshot)
+	sc.Step(`I take a snapshot of index "([^"]*)" as "([^"]*)"$`, stepArgs{"index", "name"}, func(index, name string) error {
+		return m.takeSnapshot(index, defaultInstance, name)
+	})
+
+	sc.Step(`the explanation for doc "([^"]*)" in the search in index "([^"]*)" of es "([^"]*)" is printed$`, stepArgs{"id", "index", "instance"}, m.printExplanation)
+	sc.Step(`the explanation for doc "([^"]*)" in the search in index "([^"]*)" is printed$`, stepArgs{"id", "index"}, func(id, index string) error {
+		return m.printExplanation(id, index, defaultInstance)
+	})
+
+	sc.Step(`the explanation for doc "([^"]*)" in search "([^"]*)" is printed$`, stepArgs{"id", "search"}, m.printNamedSearchExplanation)
+
+	sc.Step(`index "([^"]*)" is refreshed in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.refreshIndex)
+	sc.Step(`index "([^"]*)" is refreshed$`, stepArgs{"index"}, func(index string) error {
+		return m.refreshIndex(index, defaultInstance)
+	})
+
+	sc.Step(`index "([^"]*)" is reindexed into index "([^"]*)" of es "([^"]*)"$`, stepArgs{"source", "dest", "instance"}, func(source, dest, instance string) error {
+		return m.reindex(source, dest, instance, nil)
+	})
+	sc.Step(`index "([^"]*)" is reindexed into index "([^"]*)"$`, stepArgs{"source", "dest"}, func(source, dest string) error {
+		return m.reindex(source, dest, defaultInstance, nil)
+	})
+
+	sc.Step(`index "([^"]*)" is reindexed into index "([^"]*)" of es "([^"]*)" with[:]?$`, stepArgs{"source", "dest", "instance"}, m.reindex)
+	sc.Step(`index "([^"]*)" is reindexed into index "([^"]*)" with[:]?$`, stepArgs{"source", "dest"}, func(source, dest string, body *godog.DocString) error {
+		return m.reindex(source, dest, defaultInstance, body)
+	})
+
+	sc.Step(`docs in index "([^"]*)" of es "([^"]*)" are updated by query[:]?$`, stepArgs{"index", "instance"}, m.updateDocsByQuery)
+	sc.Step(`docs in index "([^"]*)" are updated by query[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
+		return m.updateDocsByQuery(index, defaultInstance, body)
+	})
+
+	sc.Step(`index alias "([^"]*)" of es "([^"]*)" is rolled over$`, stepArgs{"alias", "instance"}, m.rollover)
+	sc.Step(`index alias "([^"]*)" is rolled over$`, stepArgs{"alias"}, func(alias string) error {
+		return m.rollover(alias, defaultInstance)
+	})
 }
 
-func (m *Manager) registerAssertions(sc *godog.ScenarioContext) {
-	sc.Step(`index "([^"]*)" exists in es "([^"]*)"$`, m.assertIndexExists)
-	sc.Step(`index "([^"]*)" exists$`, func(index string) error {
+// nolint: funlen
+func (m *Manager) registerAssertions(sc stepRegistrar) {
+	sc.Step(`index "([^"]*)" exists in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.assertIndexExists)
+	sc.Step(`index "([^"]*)" exists$`, stepArgs{"index"}, func(index string) error {
 		return m.assertIndexExists(index, defaultInstance)
 	})
 
-	sc.Step(`index "([^"]*)" does not exist in es "([^"]*)"$`, m.assertIndexNotExists)
-	sc.Step(`index "([^"]*)" does not exist$`, func(index string) error {
+	sc.Step(`index "([^"]*)" does not exist in es "([^"]*)"$`, stepArgs{"index", "instance"}, m.assertIndexNotExists)
+	sc.Step(`index "([^"]*)" does not exist$`, stepArgs{"index"}, func(index string) error {
 		return m.assertIndexNotExists(index, defaultInstance)
 	})
 
-	sc.Step(`no docs are available in index "([^"]*)" of es "([^"]*)"$`, m.assertNoDocs)
-	sc.Step(`no docs are available in index "([^"]*)"$`, func(index string) error {
+	sc.Step(`no docs are available in index "([^"]*)" of es "([^"]*)"$`, stepArgs{"index", "instance"}, m.assertNoDocs)
+	sc.Step(`no docs are available in index "([^"]*)"$`, stepArgs{"index"}, func(index string) error {
 		return m.assertNoDocs(index, defaultInstance)
 	})
 
-	sc.Step(`only these docs are available in index "([^"]*)" of es "([^"]*)"[:]?$`, m.assertAllDocs)
-	sc.Step(`only these docs are available in index "([^"]*)"[:]?$`, func(index string, docs *godog.DocString) error {
+	sc.Step(`(\d+) docs? (?:is|are) available in index "([^"]*)" of es "([^"]*)"$`, stepArgs{"count", "index", "instance"}, m.assertNumDocs)
+	sc.Step(`(\d+) docs? (?:is|are) available in index "([^"]*)"$`, stepArgs{"count", "index"}, func(count int, index string) error {
+		return m.assertNumDocs(count, index, defaultInstance)
+	})
+
+	sc.Step(`only these docs are available in index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.assertAllDocs)
+	sc.Step(`only these docs are available in index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, docs *godog.DocString) error {
 		return m.assertAllDocs(index, defaultInstance, docs)
 	})
 
-	sc.Step(`only docs (?:in|from) this file are available in index "([^"]*)" of es "([^"]*)"[:]?$`, m.assertAllDocsFromFile)
-	sc.Step(`only docs (?:in|from) this file are available in index "([^"]*)"[:]?$`, func(index string, body *godog.DocString) error {
+	sc.Step(`only docs (?:in|from) this file are available in index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.assertAllDocsFromFile)
+	sc.Step(`only docs (?:in|from) this file are available in index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
 		return m.assertAllDocsFromFile(index, defaultInstance, body)
 	})
 
-	sc.Step(`these docs are found in index "([^"]*)" of es "([^"]*)"[:]?$`, m.assertFoundDocs)
-	sc.Step(`these docs are found in index "([^"]*)"[:]?$`, func(index string, docs *godog.DocString) error {
+	sc.Step(`these docs are found in index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.assertFoundDocs)
+	sc.Step(`these docs are found in index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, docs *godog.DocString) error {
 		return m.assertFoundDocs(index, defaultInstance, docs)
 	})
 
-	sc.Step(`docs (?:in|from) this file are found in index "([^"]*)" of es "([^"]*)"[:]?$`, m.assertFoundDocsFromFile)
-	sc.Step(`docs (?:in|from) this file are found in index "([^"]*)"[:]?$`, func(index string, body *godog.DocString) error {
+	sc.Step(`docs (?:in|from) this file are found in index "([^"]*)" of es "([^"]*)"[:]?$`, stepArgs{"index", "instance"}, m.assertFoundDocsFromFile)
+	sc.Step(`docs (?:in|from) this file are found in index "([^"]*)"[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
 		return m.assertFoundDocsFromFile(index, defaultInstance, body)
 	})
+
+	sc.Step(`docs found by search "([^"]*)" are[:]?$`, stepArgs{"search"}, m.assertNamedSearchDocs)
+	sc.Step(`docs (?:in|from) this file are found by search "([^"]*)"[:]?$`, stepArgs{"search"}, m.assertNamedSearchDocsFromFile)
+
+	sc.Step(`the search in index "([^"]*)" of es "([^"]*)" returns ids in order[:]? (.+)$`, stepArgs{"index", "instance", "ids"}, m.assertSortedIDs)
+	sc.Step(`the search in index "([^"]*)" returns ids in order[:]? (.+)$`, stepArgs{"index", "ids"}, func(index, ids string) error {
+		return m.assertSortedIDs(index, defaultInstance, ids)
+	})
+
+	sc.Step(`search "([^"]*)" returns ids in order[:]? (.+)$`, stepArgs{"search", "ids"}, m.assertNamedSearchSortedIDs)
+	sc.Step(`doc "([^"]*)" scores higher than doc "([^"]*)"$`, stepArgs{"higher", "lower"}, m.assertScoresHigher)
+	sc.Step(`doc "([^"]*)" scores higher than doc "([^"]*)" in search "([^"]*)"$`, stepArgs{"higher", "lower", "search"}, m.assertNamedSearchScoresHigher)
+	sc.Step(`max score is (greater|less) than (-?\d+(?:\.\d+)?)$`, stepArgs{"comparison", "score"}, m.assertMaxScore)
+	sc.Step(`max score of search "([^"]*)" is (greater|less) than (-?\d+(?:\.\d+)?)$`, stepArgs{"search", "comparison", "score"}, m.assertNamedSearchMaxScore)
+
+	sc.Step(`the explanation for doc "([^"]*)" in the search in index "([^"]*)" of es "([^"]*)" is[:]?$`, stepArgs{"id", "index", "instance"}, m.assertExplanation)
+	sc.Step(`the explanation for doc "([^"]*)" in the search in index "([^"]*)" is[:]?$`, stepArgs{"id", "index"}, func(id, index string, body *godog.DocString) error {
+		return m.assertExplanation(id, index, defaultInstance, body)
+	})
+
+	sc.Step(`the explanation for doc "([^"]*)" in the search in index "([^"]*)" of es "([^"]*)" matches[:]?$`, stepArgs{"id", "index", "instance"}, m.assertExplanationMatches)
+	sc.Step(`the explanation for doc "([^"]*)" in the search in index "([^"]*)" matches[:]?$`, stepArgs{"id", "index"}, func(id, index string, body *godog.DocString) error {
+		return m.assertExplanationMatches(id, index, defaultInstance, body)
+	})
+
+	sc.Step(`analyzing "([^"]*)" with analyzer "([^"]*)" in index "([^"]*)" of es "([^"]*)" produces tokens[:]? (.*)$`, stepArgs{"text", "analyzer", "index", "instance", "tokens"}, m.assertAnalyzerTokens)
+	sc.Step(`analyzing "([^"]*)" with analyzer "([^"]*)" in index "([^"]*)" produces tokens[:]? (.*)$`, stepArgs{"text", "analyzer", "index", "tokens"}, func(text, analyzer, index, tokens string) error {
+		return m.assertAnalyzerTokens(text, analyzer, index, defaultInstance, tokens)
+	})
+
+	sc.Step(`analyzing "([^"]*)" with field "([^"]*)" in index "([^"]*)" of es "([^"]*)" produces tokens[:]? (.*)$`, stepArgs{"text", "field", "index", "instance", "tokens"}, m.assertFieldTokens)
+	sc.Step(`analyzing "([^"]*)" with field "([^"]*)" in index "([^"]*)" produces tokens[:]? (.*)$`, stepArgs{"text", "field", "index", "tokens"}, func(text, field, index, tokens string) error {
+		return m.assertFieldTokens(text, field, index, defaultInstance, tokens)
+	})
+
+	sc.Step(`index "([^"]*)" of es "([^"]*)" changed from snapshot "([^"]*)" by[:]?$`, stepArgs{"index", "instance", "snapshot"}, m.assertChangedFromSnapshot)
+	sc.Step(`index "([^"]*)" changed from snapshot "([^"]*)" by[:]?$`, stepArgs{"index", "snapshot"}, func(index, name string, body *godog.DocString) error {
+		return m.assertChangedFromSnapshot(index, defaultInstance, name, body)
+	})
+
+	sc.Step(`index "([^"]*)" of es "([^"]*)" is in lifecycle phase "([^"]*)"$`, stepArgs{"index", "instance", "phase"}, m.assertLifecyclePhase)
+	sc.Step(`index "([^"]*)" is in lifecycle phase "([^"]*)"$`, stepArgs{"index", "phase"}, func(index, phase string) error {
+		return m.assertLifecyclePhase(index, defaultInstance, phase)
+	})
+
+	sc.Step(`index "([^"]*)" of es "([^"]*)" is in lifecycle step "([^"]*)"$`, stepArgs{"index", "instance", "step"}, m.assertLifecycleStep)
+	sc.Step(`index "([^"]*)" is in lifecycle step "([^"]*)"$`, stepArgs{"index", "step"}, func(index, step string) error {
+		return m.assertLifecycleStep(index, defaultInstance, step)
+	})
+
+	sc.Step(`the search response is[:]?$`, nil, m.assertSearchResponse)
+	sc.Step(`the search response matches[:]?$`, nil, m.assertSearchResponseMatches)
+
+	m.registerErrorAssertions(sc)
+
+	sc.Step(`indexing these docs into index "([^"]*)" of es "([^"]*)" fails with[:]?$`, stepArgs{"index", "instance"}, m.assertIndexDocsFailed)
+	sc.Step(`indexing these docs into index "([^"]*)" fails with[:]?$`, stepArgs{"index"}, func(index string, body *godog.DocString) error {
+		return m.assertIndexDocsFailed(index, defaultInstance, body)
+	})
+}
+
+// nolint: funlen
+func (m *Manager) registerErrorAssertions(sc stepRegistrar) {
+	sc.Step(`creating index "([^"]*)" in es "([^"]*)" fails with status (\d+)$`, stepArgs{"index", "instance", "status"}, m.assertCreateIndexFailsWithStatus)
+	sc.Step(`creating index "([^"]*)" fails with status (\d+)$`, stepArgs{"index", "status"}, func(index string, status int) error {
+		return m.assertCreateIndexFailsWithStatus(index, defaultInstance, status)
+	})
+
+	sc.Step(`creating index "([^"]*)" in es "([^"]*)" fails with error containing "([^"]*)"$`, stepArgs{"index", "instance", "message"}, m.assertCreateIndexFailsWithError)
+	sc.Step(`creating index "([^"]*)" fails with error containing "([^"]*)"$`, stepArgs{"index", "message"}, func(index, message string) error {
+		return m.assertCreateIndexFailsWithError(index, defaultInstance, message)
+	})
+
+	sc.Step(`creating index "([^"]*)" in es "([^"]*)" with config fails with status (\d+)[:]?$`, stepArgs{"index", "instance", "status"}, m.assertCreateIndexWithConfigFailsWithStatus)
+	sc.Step(`creating index "([^"]*)" with config fails with status (\d+)[:]?$`, stepArgs{"index", "status"}, func(index string, status int, config *godog.DocString) error {
+		return m.assertCreateIndexWithConfigFailsWithStatus(index, defaultInstance, status, config)
+	})
+
+	sc.Step(`creating index "([^"]*)" in es "([^"]*)" with config fails with error containing "([^"]*)"[:]?$`, stepArgs{"index", "instance", "message"}, m.assertCreateIndexWithConfigFailsWithError)
+	sc.Step(`creating index "([^"]*)" with config fails with error containing "([^"]*)"[:]?$`, stepArgs{"index", "message"}, func(index, message string, config *godog.DocString) error {
+		return m.assertCreateIndexWithConfigFailsWithError(index, defaultInstance, message, config)
+	})
+
+	sc.Step(`creating index "([^"]*)" in es "([^"]*)" with config from file fails with status (\d+)[:]?$`, stepArgs{"index", "instance", "status"}, m.assertCreateIndexWithConfigFromFileFailsWithStatus)
+	sc.Step(`creating index "([^"]*)" with config from file fails with status (\d+)[:]?$`, stepArgs{"index", "status"}, func(index string, status int, body *godog.DocString) error {
+		return m.assertCreateIndexWithConfigFromFileFailsWithStatus(index, defaultInstance, status, body)
+	})
+
+	sc.Step(`creating index "([^"]*)" in es "([^"]*)" with config from file fails with error containing "([^"]*)"[:]?$`, stepArgs{"index", "instance", "message"}, m.assertCreateIndexWithConfigFromFileFailsWithError)
+	sc.Step(`creating index "([^"]*)" with config from file fails with error containing "([^"]*)"[:]?$`, stepArgs{"index", "message"}, func(index, message string, body *godog.DocString) error {
+		return m.assertCreateIndexWithConfigFromFileFailsWithError(index, defaultInstance, message, body)
+	})
 }
 
 // RegisterContext registers the manager to the test suite.
 func (m *Manager) RegisterContext(sc *godog.ScenarioContext) {
 	sc.Before(func(context.Context, *godog.Scenario) (context.Context, error) {
-		m.queries = make(map[string]map[string]*string)
+		m.queries = make(map[string]map[string]*search)
+		m.namedSearches = make(map[string]*search)
+		m.lastSearch = nil
+		m.snapshots = make(map[string]docSnapshot)
+
+		if m.requestLogOutput != nil {
+			m.requestLog = &RequestLog{}
+		}
 
 		return nil, nil
 	})
 
-	m.registerPrerequisites(sc)
-	m.registerActions(sc)
-	m.registerAssertions(sc)
+	sc.After(func(_ context.Context, s *godog.Scenario, err error) (context.Context, error) {
+		if err == nil || m.requestLog == nil {
+			return nil, nil
+		}
+
+		if _, err := fmt.Fprintf(m.requestLogOutput, "Requests to %s in scenario %q:\n", m.vocabulary.noun(), s.Name); err != nil {
+			return nil, err
+		}
+
+		_, err = m.requestLog.WriteTo(m.requestLogOutput)
+
+		return nil, err
+	})
+
+	if m.tracerProvider != nil {
+		m.registerTracing(sc)
+	}
+
+	r := vocabularyRegistrar{scenario: sc, vocabulary: m.vocabulary}
+
+	m.registerPrerequisites(r)
+	m.registerActions(r)
+	m.registerAssertions(r)
 }
 
 func (m *Manager) createIndex(index, instance string) error {
@@ -154,13 +487,18 @@ func (m *Manager) createIndex(index, instance string) error {
 }
 
 func (m *Manager) createIndexWithConfig(index, instance string, body *godog.DocString) error {
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
 	var config *string
 
 	if body != nil {
 		config = &body.Content
 	}
 
-	return m.client(instance).CreateIndex(context.Background(), index, config)
+	return c.CreateIndex(m.ctx(), index, config)
 }
 
 func (m *Manager) createIndexWithConfigFromFile(index, instance string, body *godog.DocString) error {
@@ -177,13 +515,18 @@ func (m *Manager) recreateIndex(index, instance string) error {
 }
 
 func (m *Manager) recreateIndexWithConfig(index, instance string, body *godog.DocString) error {
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
 	var config *string
 
 	if body != nil {
 		config = &body.Content
 	}
 
-	return m.client(instance).RecreateIndex(context.Background(), index, config)
+	return c.RecreateIndex(m.ctx(), index, config)
 }
 
 func (m *Manager) recreateIndexWithConfigFromFile(index, instance string, body *godog.DocString) error {
@@ -196,21 +539,63 @@ func (m *Manager) recreateIndexWithConfigFromFile(index, instance string, body *
 }
 
 func (m *Manager) deleteIndex(index, instance string) error {
-	return m.client(instance).DeleteIndex(context.Background(), index)
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteIndex(m.ctx(), index)
 }
 
 func (m *Manager) truncateIndex(index, instance string) error {
-	return m.client(instance).DeleteAllDocuments(context.Background(), index)
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteAllDocuments(m.ctx(), index)
+}
+
+func (m *Manager) deleteDoc(id, index, instance string) error {
+	c, err := m.remover(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteDocument(m.ctx(), index, id)
+}
+
+func (m *Manager) deleteDocsByQuery(index, instance string, query *godog.DocString) error {
+	c, err := m.remover(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteDocumentsByQuery(m.ctx(), index, query.Content)
+}
+
+func (m *Manager) updateDoc(id, index, instance string, body *godog.DocString) error {
+	c, err := m.updater(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateDocument(m.ctx(), index, id, body.Content)
 }
 
 func (m *Manager) indexDocs(index, instance string, body *godog.DocString) error {
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
 	var docs []Document
 
 	if err := json.Unmarshal([]byte(body.Content), &docs); err != nil {
 		return fmt.Errorf("could not read documents for indexing: %w", err)
 	}
 
-	return m.client(instance).IndexDocuments(context.Background(), index, docs...)
+	return c.IndexDocuments(m.ctx(), index, docs...)
 }
 
 func (m *Manager) indexDocsFromFile(index, instance string, body *godog.DocString) error {
@@ -223,23 +608,136 @@ func (m *Manager) indexDocsFromFile(index, instance string, body *godog.DocStrin
 }
 
 func (m *Manager) findDocuments(index, instance string, query *godog.DocString) error {
-	if _, ok := m.queries[instance]; !ok {
-		m.queries[instance] = make(map[string]*string)
+	return m.setSearch(index, instance, &search{query: &query.Content})
+}
+
+func (m *Manager) findDocumentsAs(index, instance, name string, query *godog.DocString) error {
+	s := &search{query: &query.Content}
+
+	if err := m.runSearchStep(index, instance, s); err != nil {
+		return err
+	}
+
+	m.namedSearches[name] = s
+
+	return nil
+}
+
+func (m *Manager) findDocumentsWithQueryFromFile(index, instance string, body *godog.DocString) error {
+	query, err := os.ReadFile(body.Content)
+	if err != nil {
+		return fmt.Errorf("could not read query from file %q: %w", body.Content, err)
+	}
+
+	return m.findDocuments(index, instance, &godog.DocString{Content: string(query)})
+}
+
+func (m *Manager) findDocumentsWithTemplate(index, instance string, body *godog.DocString) error {
+	return m.setSearch(index, instance, &search{template: &body.Content})
+}
+
+func (m *Manager) findDocumentsWithStoredTemplate(index, instance, id string, params *godog.DocString) error {
+	template := struct {
+		ID     string          `json:"id"`
+		Params json.RawMessage `json:"params,omitempty"`
+	}{
+		ID: id,
+	}
+
+	if params != nil && len(params.Content) > 0 {
+		template.Params = json.RawMessage(params.Content)
+	}
+
+	body, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("could not read template params: %w", err)
+	}
+
+	return m.findDocumentsWithTemplate(index, instance, &godog.DocString{Content: string(body)})
+}
+
+func (m *Manager) setSearch(index, instance string, s *search) error {
+	if err := m.runSearchStep(index, instance, s); err != nil {
+		return err
 	}
 
-	m.queries[instance][index] = &query.Content
+	name := m.instanceName(instance)
+
+	if _, ok := m.queries[name]; !ok {
+		m.queries[name] = make(map[string]*search)
+	}
+
+	m.queries[name][index] = s
+
+	return nil
+}
+
+// storedSearch returns the search of the index set up by a search step, if any.
+func (m *Manager) storedSearch(index, instance string) *search {
+	return m.queries[m.instanceName(instance)][index]
+}
+
+// runSearchStep runs the search of a search step and keeps the response for the assertions.
+func (m *Manager) runSearchStep(index, instance string, s *search) error {
+	s.index = index
+	s.instance = instance
+
+	resp, err := m.searchResponse(index, instance, s)
+	if err != nil {
+		return err
+	}
+
+	s.response = resp
+	m.lastSearch = s
 
 	return nil
 }
 
+func (m *Manager) storeSearchTemplate(id, instance string, body *godog.DocString) error {
+	c, err := m.templateStorer(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.StoreSearchTemplate(m.ctx(), id, body.Content)
+}
+
+func (m *Manager) storeSearchTemplateFromFile(id, instance string, body *godog.DocString) error {
+	source, err := os.ReadFile(body.Content)
+	if err != nil {
+		return fmt.Errorf("could not read template from file %q: %w", body.Content, err)
+	}
+
+	return m.storeSearchTemplate(id, instance, &godog.DocString{Content: string(source)})
+}
+
+func (m *Manager) refreshIndex(index, instance string) error {
+	c, err := m.refresher(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.RefreshIndex(m.ctx(), index)
+}
+
 func (m *Manager) assertIndexExists(index, instance string) error {
-	_, err := m.client(instance).GetIndex(context.Background(), index)
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetIndex(m.ctx(), index)
 
 	return err
 }
 
 func (m *Manager) assertIndexNotExists(index, instance string) error {
-	_, err := m.client(instance).GetIndex(context.Background(), index)
+	c, err := m.client(instance)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.GetIndex(m.ctx(), index)
 
 	if errors.Is(err, ErrIndexNotFound) {
 		return nil
@@ -249,7 +747,7 @@ func (m *Manager) assertIndexNotExists(index, instance string) error {
 }
 
 func (m *Manager) assertNoDocs(index, instance string) error {
-	docs, err := m.client(instance).FindDocuments(context.Background(), index, nil)
+	docs, err := m.runSearch(index, instance, nil)
 	numDocs := len(docs)
 
 	if numDocs > 0 {
@@ -260,7 +758,7 @@ func (m *Manager) assertNoDocs(index, instance string) error {
 }
 
 func (m *Manager) assertAllDocs(index, instance string, body *godog.DocString) error {
-	docs, err := m.client(instance).FindDocuments(context.Background(), index, nil)
+	docs, err := m.runSearch(index, instance, nil)
 	if err != nil {
 		return err
 	}
@@ -280,6 +778,10 @@ func (m *Manager) assertAllDocs(index, instance string, body *godog.DocString) e
 }
 
 func (m *Manager) assertAllDocsFromFile(index, instance string, body *godog.DocString) error {
+	if m.updateGoldenFiles {
+		return m.updateDocsFile(body.Content, index, instance, nil)
+	}
+
 	content, err := os.ReadFile(body.Content)
 	if err != nil {
 		return fmt.Errorf("could not read docs from file %q: %w", body.Content, err)
@@ -289,13 +791,35 @@ func (m *Manager) assertAllDocsFromFile(index, instance string, body *godog.DocS
 }
 
 func (m *Manager) assertFoundDocs(index, instance string, body *godog.DocString) error {
-	var query *string
+	s := m.storedSearch(index, instance)
+
+	return m.assertSearchDocs(index, instance, s, body)
+}
+
+func (m *Manager) assertNamedSearchDocs(name string, body *godog.DocString) error {
+	s, ok := m.namedSearches[name]
+	if !ok {
+		return fmt.Errorf("search %q not found", name) // nolint: goerr113
+	}
+
+	return m.assertSearchDocs(s.index, s.instance, s, body)
+}
 
-	if qs, ok := m.queries[instance]; ok {
-		query = qs[index]
+func (m *Manager) assertNamedSearchDocsFromFile(name string, body *godog.DocString) error {
+	if s, ok := m.namedSearches[name]; ok && m.updateGoldenFiles {
+		return m.updateDocsFile(body.Content, s.index, s.instance, s)
 	}
 
-	docs, err := m.client(instance).FindDocuments(context.Background(), index, query)
+	content, err := os.ReadFile(body.Content)
+	if err != nil {
+		return fmt.Errorf("could not read docs from file %q: %w", body.Content, err)
+	}
+
+	return m.assertNamedSearchDocs(name, &godog.DocString{Content: string(content)})
+}
+
+func (m *Manager) assertSearchDocs(index, instance string, s *search, body *godog.DocString) error {
+	docs, err := m.runSearch(index, instance, s)
 	if err != nil {
 		return err
 	}
@@ -314,7 +838,361 @@ func (m *Manager) assertFoundDocs(index, instance string, body *godog.DocString)
 	return nil
 }
 
+// searchHits is a subset of SearchResultHits that keeps the ids and the scores of the hits.
+// nolint: tagliatelle
+type searchHits struct {
+	MaxScore *float64 `json:"max_score"`
+	Hits     []struct {
+		ID    string   `json:"_id"`
+		Score *float64 `json:"_score"`
+	} `json:"hits"`
+}
+
+func (h *searchHits) score(id string) (float64, error) {
+	for _, hit := range h.Hits {
+		if hit.ID != id {
+			continue
+		}
+
+		if hit.Score == nil {
+			return 0, fmt.Errorf("doc %q has no score", id) // nolint: goerr113
+		}
+
+		return *hit.Score, nil
+	}
+
+	return 0, fmt.Errorf("doc %q is not found", id) // nolint: goerr113
+}
+
+// runSearch returns the hits of the search, without a search all the docs of the index are returned. The hits keep
+// their `_index` only when the search targets more than one index.
+func (m *Manager) runSearch(index, instance string, s *search) ([]json.RawMessage, error) {
+	resp, err := m.searchResponse(index, instance, s)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []map[string]json.RawMessage `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("could not read search response: %w", err)
+	}
+
+	multiIndex, err := m.targetsManyIndices(index, instance, result.Hits.Hits)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]json.RawMessage, len(result.Hits.Hits))
+
+	for k, v := range result.Hits.Hits {
+		if !multiIndex {
+			delete(v, "_index")
+		}
+
+		hits[k], _ = json.Marshal(v) // nolint: errcheck,errchkjson
+	}
+
+	return hits, nil
+}
+
+// targetsManyIndices tells whether the search targets more than one index: a list, a pattern, `_all`, or an alias of
+// several indices.
+func (m *Manager) targetsManyIndices(index, instance string, hits []map[string]json.RawMessage) (bool, error) {
+	if index == "_all" || strings.ContainsAny(index, ",*") {
+		return true, nil
+	}
+
+	// The hits of a concrete index have its name, the other names come from the indices of an alias.
+	alias := false
+
+	for _, hit := range hits {
+		var name string
+
+		if v, ok := hit["_index"]; ok && json.Unmarshal(v, &name) == nil && name != index {
+			alias = true
+
+			break
+		}
+	}
+
+	if !alias {
+		return false, nil
+	}
+
+	c, err := m.client(instance)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.GetIndex(m.ctx(), index)
+	if err != nil {
+		return false, fmt.Errorf("could not get the indices of alias %q: %w", index, err)
+	}
+
+	var indices map[string]json.RawMessage
+
+	if err := json.Unmarshal(resp, &indices); err != nil {
+		return false, fmt.Errorf("could not read the indices of alias %q: %w", index, err)
+	}
+
+	return len(indices) > 1, nil
+}
+
+func (m *Manager) countDocs(index, instance string) (int, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `{"size":0,"track_total_hits":true}`
+	q := &query
+
+	// Without DocumentSearcher, the total is the number of the docs found.
+	if _, ok := c.(DocumentSearcher); !ok {
+		q = nil
+	}
+
+	resp, err := m.searchDocuments(c, index, q)
+	if err != nil {
+		return 0, err
+	}
+
+	var result SearchResult
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return 0, fmt.Errorf("could not read search response: %w", err)
+	}
+
+	return result.Hits.Total.Value, nil
+}
+
+func (m *Manager) assertNumDocs(count int, index, instance string) error {
+	numDocs, err := m.countDocs(index, instance)
+	if err != nil {
+		return err
+	}
+
+	if numDocs != count {
+		return fmt.Errorf("expected %d docs in index %q, got %d", count, index, numDocs) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func (m *Manager) assertSearchResponse(body *godog.DocString) error {
+	return m.compareSearchResponse(body, assertjson.FailNotEqual)
+}
+
+func (m *Manager) assertSearchResponseMatches(body *godog.DocString) error {
+	return m.compareSearchResponse(body, assertjson.FailMismatch)
+}
+
+func (m *Manager) compareSearchResponse(body *godog.DocString, compare func(expected, actual []byte) error) error {
+	s := m.lastSearch
+
+	if s == nil {
+		return errors.New("no search in the scenario") // nolint: goerr113
+	}
+
+	if err := compare([]byte(body.Content), s.response); err != nil {
+		return fmt.Errorf("failed to compare search response: %w", err)
+	}
+
+	return nil
+}
+
+// searchResponse returns the response of the search step, or runs the search if the step has not run it yet. Without
+// a search, all the docs of the index are searched.
+func (m *Manager) searchResponse(index, instance string, s *search) (json.RawMessage, error) {
+	if s != nil && s.response != nil {
+		return s.response, nil
+	}
+
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case s == nil:
+		return m.searchDocuments(c, index, nil)
+
+	case s.template != nil:
+		ts, ok := c.(DocumentSearcher)
+		if !ok {
+			return nil, m.notSupported(instance, "DocumentSearcher")
+		}
+
+		return ts.SearchDocumentsByTemplate(m.ctx(), index, *s.template)
+	}
+
+	return m.searchDocuments(c, index, s.query)
+}
+
+// searchDocuments searches with DocumentSearcher, or with DocumentFinder when the client does not implement it, then
+// the response only has the hits and their total.
+func (m *Manager) searchDocuments(c Client, index string, query *string) (json.RawMessage, error) {
+	if s, ok := c.(DocumentSearcher); ok {
+		return s.SearchDocuments(m.ctx(), index, query)
+	}
+
+	hits, err := c.FindDocuments(m.ctx(), index, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if hits == nil {
+		hits = []json.RawMessage{}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"hits": map[string]interface{}{
+			"total": map[string]interface{}{"value": len(hits), "relation": "eq"},
+			"hits":  hits,
+		},
+	})
+}
+
+func (m *Manager) searchHits(index, instance string, s *search) (*searchHits, error) {
+	resp, err := m.searchResponse(index, instance, s)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hits searchHits `json:"hits"`
+	}
+
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("could not read search response: %w", err)
+	}
+
+	return &result.Hits, nil
+}
+
+func (m *Manager) assertSortedIDs(index, instance, ids string) error {
+	s := m.storedSearch(index, instance)
+
+	return m.assertSearchSortedIDs(index, instance, s, ids)
+}
+
+func (m *Manager) assertNamedSearchSortedIDs(name, ids string) error {
+	s, ok := m.namedSearches[name]
+	if !ok {
+		return fmt.Errorf("search %q not found", name) // nolint: goerr113
+	}
+
+	return m.assertSearchSortedIDs(s.index, s.instance, s, ids)
+}
+
+func (m *Manager) assertSearchSortedIDs(index, instance string, s *search, ids string) error {
+	hits, err := m.searchHits(index, instance, s)
+	if err != nil {
+		return err
+	}
+
+	expected := splitList(ids)
+	actual := make([]string, len(hits.Hits))
+
+	for i, h := range hits.Hits {
+		actual[i] = h.ID
+	}
+
+	if !equalStrings(expected, actual) {
+		return fmt.Errorf("expected ids in order %s, got %s", strings.Join(expected, ", "), strings.Join(actual, ", ")) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func (m *Manager) assertScoresHigher(higher, lower string) error {
+	if m.lastSearch == nil {
+		return errors.New("no search in the scenario") // nolint: goerr113
+	}
+
+	return m.assertSearchScoresHigher(m.lastSearch, higher, lower)
+}
+
+func (m *Manager) assertNamedSearchScoresHigher(higher, lower, name string) error {
+	s, ok := m.namedSearches[name]
+	if !ok {
+		return fmt.Errorf("search %q not found", name) // nolint: goerr113
+	}
+
+	return m.assertSearchScoresHigher(s, higher, lower)
+}
+
+func (m *Manager) assertSearchScoresHigher(s *search, higher, lower string) error {
+	hits, err := m.searchHits(s.index, s.instance, s)
+	if err != nil {
+		return err
+	}
+
+	higherScore, err := hits.score(higher)
+	if err != nil {
+		return err
+	}
+
+	lowerScore, err := hits.score(lower)
+	if err != nil {
+		return err
+	}
+
+	if higherScore <= lowerScore {
+		return fmt.Errorf("doc %q scores %v, which is not higher than %v of doc %q", higher, higherScore, lowerScore, lower) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func (m *Manager) assertMaxScore(comparison string, score float64) error {
+	if m.lastSearch == nil {
+		return errors.New("no search in the scenario") // nolint: goerr113
+	}
+
+	return m.assertSearchMaxScore(m.lastSearch, comparison, score)
+}
+
+func (m *Manager) assertNamedSearchMaxScore(name, comparison string, score float64) error {
+	s, ok := m.namedSearches[name]
+	if !ok {
+		return fmt.Errorf("search %q not found", name) // nolint: goerr113
+	}
+
+	return m.assertSearchMaxScore(s, comparison, score)
+}
+
+func (m *Manager) assertSearchMaxScore(s *search, comparison string, score float64) error {
+	hits, err := m.searchHits(s.index, s.instance, s)
+	if err != nil {
+		return err
+	}
+
+	if hits.MaxScore == nil {
+		return errors.New("max score is not available") // nolint: goerr113
+	}
+
+	maxScore := *hits.MaxScore
+
+	if (comparison == "greater" && maxScore <= score) || (comparison == "less" && maxScore >= score) {
+		return fmt.Errorf("max score %v is not %s than %v", maxScore, comparison, score) // nolint: goerr113
+	}
+
+	return nil
+}
+
 func (m *Manager) assertFoundDocsFromFile(index, instance string, body *godog.DocString) error {
+	if m.updateGoldenFiles {
+		s := m.storedSearch(index, instance)
+
+		return m.updateDocsFile(body.Content, index, instance, s)
+	}
+
 	content, err := os.ReadFile(body.Content)
 	if err != nil {
 		return fmt.Errorf("could not read docs from file %q: %w", body.Content, err)
@@ -323,6 +1201,132 @@ func (m *Manager) assertFoundDocsFromFile(index, instance string, body *godog.Do
 	return m.assertFoundDocs(index, instance, &godog.DocString{Content: string(content)})
 }
 
+// assertIndexDocsFailed indexes the docs of the body and compares the failures with the ones of the body.
+func (m *Manager) assertIndexDocsFailed(index, instance string, body *godog.DocString) error {
+	var expected struct {
+		Docs     json.RawMessage `json:"docs"`
+		Failures json.RawMessage `json:"failures"`
+	}
+
+	if err := json.Unmarshal([]byte(body.Content), &expected); err != nil {
+		return fmt.Errorf("could not read docs and failures: %w", err)
+	}
+
+	err := m.indexDocs(index, instance, &godog.DocString{Content: string(expected.Docs)})
+
+	var bulkErr *BulkError
+
+	if !errors.As(err, &bulkErr) {
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("all docs are indexed in index %q", index) // nolint: goerr113
+	}
+
+	actual, err := json.Marshal(bulkErr.Failures)
+	if err != nil {
+		return err
+	}
+
+	if err := assertjson.FailNotEqual(expected.Failures, actual); err != nil {
+		return fmt.Errorf("failed to compare indexing failures: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manager) assertCreateIndexFailsWithStatus(index, instance string, status int) error {
+	return assertErrorStatus(m.createIndex(index, instance), status)
+}
+
+func (m *Manager) assertCreateIndexFailsWithError(index, instance, message string) error {
+	return assertErrorContains(m.createIndex(index, instance), message)
+}
+
+func (m *Manager) assertCreateIndexWithConfigFailsWithStatus(index, instance string, status int, body *godog.DocString) error {
+	return assertErrorStatus(m.createIndexWithConfig(index, instance, body), status)
+}
+
+func (m *Manager) assertCreateIndexWithConfigFailsWithError(index, instance, message string, body *godog.DocString) error {
+	return assertErrorContains(m.createIndexWithConfig(index, instance, body), message)
+}
+
+func (m *Manager) assertCreateIndexWithConfigFromFileFailsWithStatus(index, instance string, status int, body *godog.DocString) error {
+	config, err := os.ReadFile(body.Content)
+	if err != nil {
+		return fmt.Errorf("could not read config from file %q: %w", body.Content, err)
+	}
+
+	return m.assertCreateIndexWithConfigFailsWithStatus(index, instance, status, &godog.DocString{Content: string(config)})
+}
+
+func (m *Manager) assertCreateIndexWithConfigFromFileFailsWithError(index, instance, message string, body *godog.DocString) error {
+	config, err := os.ReadFile(body.Content)
+	if err != nil {
+		return fmt.Errorf("could not read config from file %q: %w", body.Content, err)
+	}
+
+	return m.assertCreateIndexWithConfigFailsWithError(index, instance, message, &godog.DocString{Content: string(config)})
+}
+
+// splitList splits a comma-separated list of optionally quoted values.
+func splitList(list string) []string {
+	values := make([]string, 0)
+
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.Trim(strings.TrimSpace(v), `"`); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func assertErrorStatus(err error, status int) error {
+	if err == nil {
+		return fmt.Errorf("expected status %d, got no error", status) // nolint: goerr113
+	}
+
+	var statusErr StatusError
+
+	if !errors.As(err, &statusErr) {
+		return fmt.Errorf("expected status %d, got error without status: %w", status, err) // nolint: goerr113
+	}
+
+	if statusErr.StatusCode() != status {
+		return fmt.Errorf("expected status %d, got %d: %w", status, statusErr.StatusCode(), err) // nolint: goerr113
+	}
+
+	return nil
+}
+
+func assertErrorContains(err error, message string) error {
+	if err == nil {
+		return fmt.Errorf("expected error containing %q, got no error", message) // nolint: goerr113
+	}
+
+	if !strings.Contains(err.Error(), message) {
+		return fmt.Errorf("expected error containing %q, got: %w", message, err) // nolint: goerr113
+	}
+
+	return nil
+}
+
 // ManagerOption sets up the manager.
 type ManagerOption func(m *Manager)
 
@@ -332,7 +1336,14 @@ func NewManager(client Client, opts ...ManagerOption) *Manager {
 		instances: map[string]Client{
 			defaultInstance: client,
 		},
-		queries: map[string]map[string]*string{},
+		queries:       map[string]map[string]*search{},
+		namedSearches: map[string]*search{},
+		snapshots:     map[string]docSnapshot{},
+		output:        os.Stdout,
+
+		defaultInstanceName: defaultInstance,
+		lifecycleTimeout:    defaultLifecycleTimeout,
+		updateGoldenFiles:   updateGoldenFilesFromEnv(),
 	}
 
 	for _, o := range opts {
@@ -342,6 +1353,38 @@ func NewManager(client Client, opts ...ManagerOption) *Manager {
 	return m
 }
 
+// WithOutput sets the writer for the steps that print, for example the explanations. Default is os.Stdout.
+func WithOutput(w io.Writer) ManagerOption {
+	return func(m *Manager) {
+		m.output = w
+	}
+}
+
+// WithGoldenFilesUpdate enables or disables the update mode of the golden files. When enabled, the steps that check
+// the docs from a file rewrite the file with the actual docs instead of failing. By default, the mode is enabled when
+// the ELASTICSTEPS_UPDATE_GOLDEN_FILES env var is true.
+func WithGoldenFilesUpdate(enabled bool) ManagerOption {
+	return func(m *Manager) {
+		m.updateGoldenFiles = enabled
+	}
+}
+
+// WithDefaultInstanceName names the default es instance, so it could also be used in the steps with
+// `in es "..."` or `of es "..."`. Default is "_default".
+func WithDefaultInstanceName(name string) ManagerOption {
+	return func(m *Manager) {
+		m.defaultInstanceName = name
+	}
+}
+
+// WithLifecycleTimeout sets how long the lifecycle assertions wait for the expected phase or step of an index, the
+// policies run every `indices.lifecycle.poll_interval`. Default is 30 seconds.
+func WithLifecycleTimeout(timeout time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.lifecycleTimeout = timeout
+	}
+}
+
 // WithInstance adds a new es instance.
 func WithInstance(name string, client Client) ManagerOption {
 	return func(m *Manager) {
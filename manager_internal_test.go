@@ -79,6 +79,127 @@ func TestManager_createIndexWithConfigFromFile_NotFound(t *testing.T) {
 	assert.EqualError(t, err, expected)
 }
 
+func TestManager_assertCreateIndexWithConfigFailsWithStatus(t *testing.T) {
+	t.Parallel()
+
+	body := `{"mappings":{"properties":{"size":{"type":"unknown"}}}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		expectedError string
+	}{
+		{
+			scenario: "no error",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(nil)
+			}),
+			expectedError: `expected status 400, got no error`,
+		},
+		{
+			scenario: "no status",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(errors.New("create error"))
+			}),
+			expectedError: `expected status 400, got error without status: create error`,
+		},
+		{
+			scenario: "different status",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(statusError{code: 409, message: "resource_already_exists_exception"})
+			}),
+			expectedError: `expected status 400, got 409: resource_already_exists_exception`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(fmt.Errorf("could not create index: %w", statusError{code: 400, message: "mapper_parsing_exception"}))
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertCreateIndexWithConfigFailsWithStatus(index, instance, 400, &godog.DocString{Content: body})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertCreateIndexWithConfigFailsWithError(t *testing.T) {
+	t.Parallel()
+
+	body := `{"mappings":{"properties":{"size":{"type":"unknown"}}}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		expectedError string
+	}{
+		{
+			scenario: "no error",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(nil)
+			}),
+			expectedError: `expected error containing "mapper_parsing_exception", got no error`,
+		},
+		{
+			scenario: "different error",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(errors.New("resource_already_exists_exception"))
+			}),
+			expectedError: `expected error containing "mapper_parsing_exception", got: resource_already_exists_exception`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("CreateIndex", context.Background(), index, &body).
+					Return(errors.New(`[400 Bad Request] {"error":{"type":"mapper_parsing_exception"}}`))
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertCreateIndexWithConfigFailsWithError(index, instance, "mapper_parsing_exception", &godog.DocString{Content: body})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertCreateIndexWithConfigFromFileFailsWithStatus_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+	err := m.assertCreateIndexWithConfigFromFileFailsWithStatus(index, instance, 400, &godog.DocString{Content: "unknown"})
+
+	expected := `could not read config from file "unknown": open unknown: no such file or directory`
+
+	assert.EqualError(t, err, expected)
+}
+
 func TestManager_recreateIndex(t *testing.T) {
 	t.Parallel()
 
@@ -209,66 +330,28 @@ func TestManager_truncateIndex(t *testing.T) {
 	}
 }
 
-func TestManager_indexDocs(t *testing.T) {
+func TestManager_refreshIndex(t *testing.T) {
 	t.Parallel()
 
-	const validPayload = `[
-	{
-		"_id": "41",
-		"_source": {
-			"handle": "item-41",
-			"name": "Item 41",
-			"locale": "en_US"
-		}
-	},
-	{
-		"_id": "42",
-		"_source": {
-			"handle": "item-42",
-			"name": "Item 42",
-			"locale": "en_US"
-		}
-	}
-]`
-
 	testCases := []struct {
-		scenario      string
-		mock          managerMocker
-		payload       string
-		expectedError string
+		scenario string
+		mock     managerMocker
+		expected error
 	}{
-		{
-			scenario:      "invalid payload",
-			mock:          mockManager(),
-			payload:       `{`,
-			expectedError: `could not read documents for indexing: unexpected end of JSON input`,
-		},
 		{
 			scenario: "failure",
 			mock: mockManager(func(c *client) {
-				c.On("IndexDocuments", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Return(errors.New("index error"))
+				c.On("RefreshIndex", mock.Anything, mock.Anything).
+					Return(errors.New("refresh error"))
 			}),
-			payload:       validPayload,
-			expectedError: `index error`,
+			expected: errors.New("refresh error"),
 		},
 		{
 			scenario: "success",
 			mock: mockManager(func(c *client) {
-				c.On("IndexDocuments", context.Background(), index,
-					Document{
-						ID:     "41",
-						Source: json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`),
-					},
-					Document{
-						ID:     "42",
-						Source: json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US"}`),
-					},
-				).
-					Return(errors.New("index error"))
+				c.On("RefreshIndex", context.Background(), index).
+					Return(nil)
 			}),
-			payload:       validPayload,
-			expectedError: `index error`,
 		},
 	}
 
@@ -277,29 +360,12 @@ func TestManager_indexDocs(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			err := tc.mock(t).indexDocs(index, instance, &godog.DocString{Content: tc.payload})
-
-			if tc.expectedError == "" {
-				assert.NoError(t, err)
-			} else {
-				assert.EqualError(t, err, tc.expectedError)
-			}
+			assert.Equal(t, tc.expected, tc.mock(t).refreshIndex(index, instance))
 		})
 	}
 }
 
-func TestManager_indexDocsFromFile_NotFound(t *testing.T) {
-	t.Parallel()
-
-	m := mockManager()(t)
-	err := m.indexDocsFromFile(index, instance, &godog.DocString{Content: "unknown"})
-
-	expected := `could not read docs from file "unknown": open unknown: no such file or directory`
-
-	assert.EqualError(t, err, expected)
-}
-
-func TestManager_assertIndexExists(t *testing.T) {
+func TestManager_deleteDoc(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
@@ -310,16 +376,16 @@ func TestManager_assertIndexExists(t *testing.T) {
 		{
 			scenario: "failure",
 			mock: mockManager(func(c *client) {
-				c.On("GetIndex", mock.Anything, mock.Anything).
-					Return(nil, errors.New("get error"))
+				c.On("DeleteDocument", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("delete error"))
 			}),
-			expected: errors.New("get error"),
+			expected: errors.New("delete error"),
 		},
 		{
 			scenario: "success",
 			mock: mockManager(func(c *client) {
-				c.On("GetIndex", context.Background(), index).
-					Return(nil, nil)
+				c.On("DeleteDocument", context.Background(), index, "42").
+					Return(nil)
 			}),
 		},
 	}
@@ -329,14 +395,16 @@ func TestManager_assertIndexExists(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			assert.Equal(t, tc.expected, tc.mock(t).assertIndexExists(index, instance))
+			assert.Equal(t, tc.expected, tc.mock(t).deleteDoc("42", index, instance))
 		})
 	}
 }
 
-func TestManager_assertIndexNotExists(t *testing.T) {
+func TestManager_deleteDocsByQuery(t *testing.T) {
 	t.Parallel()
 
+	query := `{"query":{"term":{"locale":"en_US"}}}`
+
 	testCases := []struct {
 		scenario string
 		mock     managerMocker
@@ -345,23 +413,16 @@ func TestManager_assertIndexNotExists(t *testing.T) {
 		{
 			scenario: "failure",
 			mock: mockManager(func(c *client) {
-				c.On("GetIndex", mock.Anything, mock.Anything).
-					Return(nil, errors.New("get error"))
-			}),
-			expected: errors.New("get error"),
-		},
-		{
-			scenario: "success when index does not exist",
-			mock: mockManager(func(c *client) {
-				c.On("GetIndex", context.Background(), index).
-					Return(nil, ErrIndexNotFound)
+				c.On("DeleteDocumentsByQuery", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("delete error"))
 			}),
+			expected: errors.New("delete error"),
 		},
 		{
 			scenario: "success",
 			mock: mockManager(func(c *client) {
-				c.On("GetIndex", context.Background(), index).
-					Return(nil, nil)
+				c.On("DeleteDocumentsByQuery", context.Background(), index, query).
+					Return(nil)
 			}),
 		},
 	}
@@ -371,40 +432,36 @@ func TestManager_assertIndexNotExists(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			assert.Equal(t, tc.expected, tc.mock(t).assertIndexNotExists(index, instance))
+			err := tc.mock(t).deleteDocsByQuery(index, instance, &godog.DocString{Content: query})
+
+			assert.Equal(t, tc.expected, err)
 		})
 	}
 }
 
-func TestManager_assertNoDocs(t *testing.T) {
+func TestManager_updateDoc(t *testing.T) {
 	t.Parallel()
 
+	update := `{"doc":{"name":"Item 42 (updated)"}}`
+
 	testCases := []struct {
-		scenario      string
-		mock          managerMocker
-		expectedError string
+		scenario string
+		mock     managerMocker
+		expected error
 	}{
 		{
 			scenario: "failure",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, errors.New("get error"))
-			}),
-			expectedError: "get error",
-		},
-		{
-			scenario: "has documents",
-			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", mock.Anything, mock.Anything, mock.Anything).
-					Return([]json.RawMessage{nil}, nil)
+				c.On("UpdateDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("update error"))
 			}),
-			expectedError: `there are 1 docs in index "test-index"`,
+			expected: errors.New("update error"),
 		},
 		{
-			scenario: "no documents",
+			scenario: "success",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", context.Background(), index, (*string)(nil)).
-					Return([]json.RawMessage{}, nil)
+				c.On("UpdateDocument", context.Background(), index, "42", update).
+					Return(nil)
 			}),
 		},
 	}
@@ -414,74 +471,172 @@ func TestManager_assertNoDocs(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			err := tc.mock(t).assertNoDocs(index, instance)
+			err := tc.mock(t).updateDoc("42", index, instance, &godog.DocString{Content: update})
 
-			if tc.expectedError == "" {
-				assert.NoError(t, err)
-			} else {
-				assert.EqualError(t, err, tc.expectedError)
-			}
+			assert.Equal(t, tc.expected, err)
 		})
 	}
 }
 
-func TestManager_assertAllDocs(t *testing.T) {
+func TestManager_indexDocs(t *testing.T) {
 	t.Parallel()
 
-	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
-	payload42 := json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US"}`)
+	const validPayload = `[
+	{
+		"_id": "41",
+		"_source": {
+			"handle": "item-41",
+			"name": "Item 41",
+			"locale": "en_US"
+		}
+	},
+	{
+		"_id": "42",
+		"_source": {
+			"handle": "item-42",
+			"name": "Item 42",
+			"locale": "en_US"
+		}
+	}
+]`
 
 	testCases := []struct {
-		scenario       string
-		mock           managerMocker
-		expectedResult string
-		expectedError  string
+		scenario      string
+		mock          managerMocker
+		payload       string
+		expectedError string
 	}{
 		{
-			scenario: "fail to get documents",
-			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", mock.Anything, mock.Anything, mock.Anything).
-					Return(nil, errors.New("get error"))
-			}),
-			expectedError: `get error`,
+			scenario:      "invalid payload",
+			mock:          mockManager(),
+			payload:       `{`,
+			expectedError: `could not read documents for indexing: unexpected end of JSON input`,
 		},
 		{
-			scenario: "invalid payload",
+			scenario: "failure",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", mock.Anything, mock.Anything, mock.Anything).
-					Return([]json.RawMessage{[]byte("}")}, nil)
-			}),
-			expectedError: "json: error calling MarshalJSON for type json.RawMessage: invalid character '}' looking for beginning of value",
+				c.On("IndexDocuments", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("index error"))
+			}),
+			payload:       validPayload,
+			expectedError: `index error`,
 		},
 		{
-			scenario: "not equal",
+			scenario: "success",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", context.Background(), index, (*string)(nil)).
-					Return([]json.RawMessage{payload41, payload42}, nil)
+				c.On("IndexDocuments", context.Background(), index,
+					Document{
+						ID:     "41",
+						Source: json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`),
+					},
+					Document{
+						ID:     "42",
+						Source: json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US"}`),
+					},
+				).
+					Return(errors.New("index error"))
 			}),
-			expectedResult: fmt.Sprintf("[%s]", payload41),
-			expectedError: `failed to compare docs: not equal:
+			payload:       validPayload,
+			expectedError: `index error`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).indexDocs(index, instance, &godog.DocString{Content: tc.payload})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_indexDocsFromFile_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+	err := m.indexDocsFromFile(index, instance, &godog.DocString{Content: "unknown"})
+
+	expected := `could not read docs from file "unknown": open unknown: no such file or directory`
+
+	assert.EqualError(t, err, expected)
+}
+
+func TestManager_assertIndexDocsFailed(t *testing.T) {
+	t.Parallel()
+
+	const docs = `[{"_id":"42","_source":{"handle":"item-42"}}]`
+
+	bulkErr := &BulkError{Failures: []BulkFailure{{
+		ID:     "42",
+		Status: 400,
+		Type:   "mapper_parsing_exception",
+		Reason: "failed to parse field [handle]",
+	}}}
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		body          string
+		expectedError string
+	}{
+		{
+			scenario: "invalid body",
+			mock: mockManager(func(*client) {
+			}),
+			body:          `{`,
+			expectedError: `could not read docs and failures: unexpected end of JSON input`,
+		},
+		{
+			scenario: "unexpected error",
+			mock: mockManager(func(c *client) {
+				c.On("IndexDocuments", mock.Anything, index, mock.Anything).
+					Return(errors.New("index error"))
+			}),
+			body:          `{"docs":` + docs + `,"failures":[]}`,
+			expectedError: `index error`,
+		},
+		{
+			scenario: "no failure",
+			mock: mockManager(func(c *client) {
+				c.On("IndexDocuments", mock.Anything, index, mock.Anything).
+					Return(nil)
+			}),
+			body:          `{"docs":` + docs + `,"failures":[]}`,
+			expectedError: `all docs are indexed in index "test-index"`,
+		},
+		{
+			scenario: "different failures",
+			mock: mockManager(func(c *client) {
+				c.On("IndexDocuments", mock.Anything, index, mock.Anything).
+					Return(fmt.Errorf("wrapped: %w", bulkErr))
+			}),
+			body: `{"docs":` + docs + `,"failures":[{"_id":"42","status":409,"reason":"<ignore-diff>"}]}`,
+			expectedError: `failed to compare indexing failures: not equal:
  [
    {
-     "handle": "item-41",
-     "locale": "en_US",
-     "name": "Item 41"
+     "_id": "42",
+     "reason": "<ignore-diff>",
+-    "status": 409
++    "status": 400
++    "type": "mapper_parsing_exception"
    }
-+  {
-+    "handle": "item-42",
-+    "locale": "en_US",
-+    "name": "Item 42"
-+  }
  ]
 `,
 		},
 		{
-			scenario: "equal",
+			scenario: "success",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", context.Background(), index, (*string)(nil)).
-					Return([]json.RawMessage{payload41, payload42}, nil)
+				c.On("IndexDocuments", mock.Anything, index, Document{ID: "42", Source: []byte(`{"handle":"item-42"}`)}).
+					Return(bulkErr)
 			}),
-			expectedResult: fmt.Sprintf("[%s,%s]", payload41, payload42),
+			body: `{"docs":` + docs + `,"failures":[{"_id":"42","status":400,"type":"mapper_parsing_exception","reason":"<ignore-diff>"}]}`,
 		},
 	}
 
@@ -490,7 +645,7 @@ func TestManager_assertAllDocs(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			err := tc.mock(t).assertAllDocs(index, instance, &godog.DocString{Content: tc.expectedResult})
+			err := tc.mock(t).assertIndexDocsFailed(index, instance, &godog.DocString{Content: tc.body})
 
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
@@ -501,18 +656,133 @@ func TestManager_assertAllDocs(t *testing.T) {
 	}
 }
 
-func TestManager_assertAllDocsFromFile_NotFound(t *testing.T) {
+func TestManager_assertIndexExists(t *testing.T) {
 	t.Parallel()
 
-	m := mockManager()(t)
-	err := m.assertAllDocsFromFile(index, instance, &godog.DocString{Content: "unknown"})
+	testCases := []struct {
+		scenario string
+		mock     managerMocker
+		expected error
+	}{
+		{
+			scenario: "failure",
+			mock: mockManager(func(c *client) {
+				c.On("GetIndex", mock.Anything, mock.Anything).
+					Return(nil, errors.New("get error"))
+			}),
+			expected: errors.New("get error"),
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("GetIndex", context.Background(), index).
+					Return(nil, nil)
+			}),
+		},
+	}
 
-	expected := `could not read docs from file "unknown": open unknown: no such file or directory`
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
 
-	assert.EqualError(t, err, expected)
+			assert.Equal(t, tc.expected, tc.mock(t).assertIndexExists(index, instance))
+		})
+	}
 }
 
-func TestManager_assertFoundDocs(t *testing.T) {
+func TestManager_assertIndexNotExists(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		mock     managerMocker
+		expected error
+	}{
+		{
+			scenario: "failure",
+			mock: mockManager(func(c *client) {
+				c.On("GetIndex", mock.Anything, mock.Anything).
+					Return(nil, errors.New("get error"))
+			}),
+			expected: errors.New("get error"),
+		},
+		{
+			scenario: "success when index does not exist",
+			mock: mockManager(func(c *client) {
+				c.On("GetIndex", context.Background(), index).
+					Return(nil, ErrIndexNotFound)
+			}),
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("GetIndex", context.Background(), index).
+					Return(nil, nil)
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.mock(t).assertIndexNotExists(index, instance))
+		})
+	}
+}
+
+func TestManager_assertNoDocs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		expectedError string
+	}{
+		{
+			scenario: "failure",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("get error"))
+			}),
+			expectedError: "get error",
+		},
+		{
+			scenario: "has documents",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", mock.Anything, mock.Anything, mock.Anything).
+					Return(hitsResponse(json.RawMessage(`{}`)), nil)
+			}),
+			expectedError: `there are 1 docs in index "test-index"`,
+		},
+		{
+			scenario: "no documents",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, (*string)(nil)).
+					Return(hitsResponse(), nil)
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertNoDocs(index, instance)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertAllDocs(t *testing.T) {
 	t.Parallel()
 
 	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
@@ -527,24 +797,24 @@ func TestManager_assertFoundDocs(t *testing.T) {
 		{
 			scenario: "fail to get documents",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", mock.Anything, mock.Anything, mock.Anything).
+				c.On("SearchDocuments", mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, errors.New("get error"))
 			}),
 			expectedError: `get error`,
 		},
 		{
-			scenario: "invalid payload",
+			scenario: "invalid response",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", mock.Anything, mock.Anything, mock.Anything).
-					Return([]json.RawMessage{[]byte("}")}, nil)
+				c.On("SearchDocuments", mock.Anything, mock.Anything, mock.Anything).
+					Return(`{`, nil)
 			}),
-			expectedError: "json: error calling MarshalJSON for type json.RawMessage: invalid character '}' looking for beginning of value",
+			expectedError: `could not read search response: unexpected end of JSON input`,
 		},
 		{
 			scenario: "not equal",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", context.Background(), index, (*string)(nil)).
-					Return([]json.RawMessage{payload41, payload42}, nil)
+				c.On("SearchDocuments", context.Background(), index, (*string)(nil)).
+					Return(hitsResponse(payload41, payload42), nil)
 			}),
 			expectedResult: fmt.Sprintf("[%s]", payload41),
 			expectedError: `failed to compare docs: not equal:
@@ -565,8 +835,8 @@ func TestManager_assertFoundDocs(t *testing.T) {
 		{
 			scenario: "equal",
 			mock: mockManager(func(c *client) {
-				c.On("FindDocuments", context.Background(), index, (*string)(nil)).
-					Return([]json.RawMessage{payload41, payload42}, nil)
+				c.On("SearchDocuments", context.Background(), index, (*string)(nil)).
+					Return(hitsResponse(payload41, payload42), nil)
 			}),
 			expectedResult: fmt.Sprintf("[%s,%s]", payload41, payload42),
 		},
@@ -577,7 +847,7 @@ func TestManager_assertFoundDocs(t *testing.T) {
 		t.Run(tc.scenario, func(t *testing.T) {
 			t.Parallel()
 
-			err := tc.mock(t).assertFoundDocs(index, instance, &godog.DocString{Content: tc.expectedResult})
+			err := tc.mock(t).assertAllDocs(index, instance, &godog.DocString{Content: tc.expectedResult})
 
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
@@ -588,18 +858,283 @@ func TestManager_assertFoundDocs(t *testing.T) {
 	}
 }
 
-func TestManager_assertFoundDocs_WithQuery(t *testing.T) {
+func TestManager_assertAllDocs_MultiIndex(t *testing.T) {
 	t.Parallel()
 
-	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
-	payload42 := json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US"}`)
-
-	query := `{"query": {"match": {}}}`
-	expected := fmt.Sprintf("[%s,%s]", payload41, payload42)
+	testCases := []struct {
+		scenario      string
+		index         string
+		mock          managerMocker
+		expected      string
+		expectedError string
+	}{
+		{
+			scenario: "search error",
+			index:    "products_en_us, products_fr_fr",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_en_us, products_fr_fr", (*string)(nil)).
+					Return(nil, errors.New("search error"))
+			}),
+			expectedError: `search error`,
+		},
+		{
+			scenario: "invalid response",
+			index:    "products_en_us, products_fr_fr",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_en_us, products_fr_fr", (*string)(nil)).
+					Return(`{`, nil)
+			}),
+			expectedError: `could not read search response: unexpected end of JSON input`,
+		},
+		{
+			scenario: "hits from several indices",
+			index:    "products_en_us, products_fr_fr",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_en_us, products_fr_fr", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_en_us","_id":"41","_source":{}},{"_index":"products_fr_fr","_id":"41","_source":{}}]}}`, nil)
+			}),
+			expected: `[{"_index":"products_en_us","_id":"41","_source":{}},{"_index":"products_fr_fr","_id":"41","_source":{}}]`,
+		},
+		{
+			scenario: "hits from one of the indices",
+			index:    "products_en_us, products_fr_fr",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_en_us, products_fr_fr", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_en_us","_id":"41","_source":{}}]}}`, nil)
+			}),
+			expected: `[{"_index":"products_en_us","_id":"41","_source":{}}]`,
+		},
+		{
+			scenario: "pattern of a single index",
+			index:    "products_fr_*",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_fr_*", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_fr_fr","_id":"41","_source":{}},{"_index":"products_fr_fr","_id":"42","_source":{}}]}}`, nil)
+			}),
+			expected: `[{"_index":"products_fr_fr","_id":"41","_source":{}},{"_index":"products_fr_fr","_id":"42","_source":{}}]`,
+		},
+		{
+			scenario: "all indices",
+			index:    "_all",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "_all", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_fr_fr","_id":"41","_source":{}}]}}`, nil)
+			}),
+			expected: `[{"_index":"products_fr_fr","_id":"41","_source":{}}]`,
+		},
+		{
+			scenario: "concrete index",
+			index:    "products_fr_fr",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_fr_fr", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_fr_fr","_id":"41","_source":{}}]}}`, nil)
+			}),
+			expected: `[{"_id":"41","_source":{}}]`,
+		},
+		{
+			scenario: "alias of several indices",
+			index:    "products",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_en_us","_id":"41","_source":{}}]}}`, nil)
 
-	m := mockManager(func(c *client) {
-		c.On("FindDocuments", context.Background(), index, &query).
-			Return([]json.RawMessage{payload41, payload42}, nil)
+				c.On("GetIndex", context.Background(), "products").
+					Return(`{"products_en_us":{},"products_fr_fr":{}}`, nil)
+			}),
+			expected: `[{"_index":"products_en_us","_id":"41","_source":{}}]`,
+		},
+		{
+			scenario: "alias of one index",
+			index:    "products",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_en_us","_id":"41","_source":{}}]}}`, nil)
+
+				c.On("GetIndex", context.Background(), "products").
+					Return(`{"products_en_us":{}}`, nil)
+			}),
+			expected: `[{"_id":"41","_source":{}}]`,
+		},
+		{
+			scenario: "alias error",
+			index:    "products",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products", (*string)(nil)).
+					Return(`{"hits":{"hits":[{"_index":"products_en_us","_id":"41","_source":{}}]}}`, nil)
+
+				c.On("GetIndex", context.Background(), "products").
+					Return(nil, errors.New("get error"))
+			}),
+			expectedError: `could not get the indices of alias "products": get error`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertAllDocs(tc.index, instance, &godog.DocString{Content: tc.expected})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertNumDocs(t *testing.T) {
+	t.Parallel()
+
+	query := `{"size":0,"track_total_hits":true}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		expectedError string
+	}{
+		{
+			scenario: "search error",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_*", &query).
+					Return(nil, errors.New("search error"))
+			}),
+			expectedError: `search error`,
+		},
+		{
+			scenario: "different count",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_*", &query).
+					Return(`{"hits":{"total":{"value":2,"relation":"eq"},"hits":[]}}`, nil)
+			}),
+			expectedError: `expected 3 docs in index "products_*", got 2`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), "products_*", &query).
+					Return(`{"hits":{"total":{"value":3,"relation":"eq"},"hits":[]}}`, nil)
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertNumDocs(3, "products_*", instance)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertAllDocsFromFile_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+	err := m.assertAllDocsFromFile(index, instance, &godog.DocString{Content: "unknown"})
+
+	expected := `could not read docs from file "unknown": open unknown: no such file or directory`
+
+	assert.EqualError(t, err, expected)
+}
+
+func TestManager_assertFoundDocs(t *testing.T) {
+	t.Parallel()
+
+	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
+	payload42 := json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US"}`)
+
+	testCases := []struct {
+		scenario       string
+		mock           managerMocker
+		expectedResult string
+		expectedError  string
+	}{
+		{
+			scenario: "fail to get documents",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("get error"))
+			}),
+			expectedError: `get error`,
+		},
+		{
+			scenario: "invalid response",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", mock.Anything, mock.Anything, mock.Anything).
+					Return(`{`, nil)
+			}),
+			expectedError: `could not read search response: unexpected end of JSON input`,
+		},
+		{
+			scenario: "not equal",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, (*string)(nil)).
+					Return(hitsResponse(payload41, payload42), nil)
+			}),
+			expectedResult: fmt.Sprintf("[%s]", payload41),
+			expectedError: `failed to compare docs: not equal:
+ [
+   {
+     "handle": "item-41",
+     "locale": "en_US",
+     "name": "Item 41"
+   }
++  {
++    "handle": "item-42",
++    "locale": "en_US",
++    "name": "Item 42"
++  }
+ ]
+`,
+		},
+		{
+			scenario: "equal",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, (*string)(nil)).
+					Return(hitsResponse(payload41, payload42), nil)
+			}),
+			expectedResult: fmt.Sprintf("[%s,%s]", payload41, payload42),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).assertFoundDocs(index, instance, &godog.DocString{Content: tc.expectedResult})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertFoundDocs_WithQuery(t *testing.T) {
+	t.Parallel()
+
+	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
+	payload42 := json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US"}`)
+
+	query := `{"query": {"match": {}}}`
+	expected := fmt.Sprintf("[%s,%s]", payload41, payload42)
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &query).
+			Return(hitsResponse(payload41, payload42), nil)
 	})(t)
 
 	err := m.findDocuments(index, instance, &godog.DocString{Content: query})
@@ -609,6 +1144,575 @@ func TestManager_assertFoundDocs_WithQuery(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestManager_assertFoundDocs_WithQueryFromFile(t *testing.T) {
+	t.Parallel()
+
+	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
+
+	query := "{\n    \"query\": {\n        \"match\": {\n            \"locale\": \"en_US\"\n        }\n    }\n}\n"
+	expected := fmt.Sprintf("[%s]", payload41)
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &query).
+			Return(hitsResponse(payload41), nil)
+	})(t)
+
+	err := m.findDocumentsWithQueryFromFile(index, instance, &godog.DocString{Content: "resources/fixtures/query_en_us.json"})
+	assert.NoError(t, err)
+
+	err = m.assertFoundDocs(index, instance, &godog.DocString{Content: expected})
+	assert.NoError(t, err)
+}
+
+func TestManager_findDocumentsWithQueryFromFile_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+	err := m.findDocumentsWithQueryFromFile(index, instance, &godog.DocString{Content: "unknown"})
+
+	expected := `could not read query from file "unknown": open unknown: no such file or directory`
+
+	assert.EqualError(t, err, expected)
+}
+
+func TestManager_assertFoundDocs_WithTemplate(t *testing.T) {
+	t.Parallel()
+
+	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US"}`)
+	expected := fmt.Sprintf("[%s]", payload41)
+
+	testCases := []struct {
+		scenario string
+		search   func(m *Manager) error
+		template string
+	}{
+		{
+			scenario: "inline",
+			search: func(m *Manager) error {
+				return m.findDocumentsWithTemplate(index, instance, &godog.DocString{
+					Content: `{"source":{"query":{"match":{"locale":"{{locale}}"}}},"params":{"locale":"en_US"}}`,
+				})
+			},
+			template: `{"source":{"query":{"match":{"locale":"{{locale}}"}}},"params":{"locale":"en_US"}}`,
+		},
+		{
+			scenario: "stored",
+			search: func(m *Manager) error {
+				return m.findDocumentsWithStoredTemplate(index, instance, "by-locale", &godog.DocString{
+					Content: `{"locale":"en_US"}`,
+				})
+			},
+			template: `{"id":"by-locale","params":{"locale":"en_US"}}`,
+		},
+		{
+			scenario: "stored without params",
+			search: func(m *Manager) error {
+				return m.findDocumentsWithStoredTemplate(index, instance, "by-locale", nil)
+			},
+			template: `{"id":"by-locale"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := mockManager(func(c *client) {
+				c.On("SearchDocumentsByTemplate", context.Background(), index, tc.template).
+					Return(hitsResponse(payload41), nil)
+			})(t)
+
+			err := tc.search(m)
+			assert.NoError(t, err)
+
+			err = m.assertFoundDocs(index, instance, &godog.DocString{Content: expected})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManager_storeSearchTemplate(t *testing.T) {
+	t.Parallel()
+
+	source := `{"query":{"match":{"locale":"{{locale}}"}}}`
+
+	testCases := []struct {
+		scenario string
+		mock     managerMocker
+		expected error
+	}{
+		{
+			scenario: "failure",
+			mock: mockManager(func(c *client) {
+				c.On("StoreSearchTemplate", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("store error"))
+			}),
+			expected: errors.New("store error"),
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("StoreSearchTemplate", context.Background(), "by-locale", source).
+					Return(nil)
+			}),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).storeSearchTemplate("by-locale", instance, &godog.DocString{Content: source})
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestManager_storeSearchTemplateFromFile_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+	err := m.storeSearchTemplateFromFile("by-locale", instance, &godog.DocString{Content: "unknown"})
+
+	expected := `could not read template from file "unknown": open unknown: no such file or directory`
+
+	assert.EqualError(t, err, expected)
+}
+
+func TestManager_assertSearchResponse(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"locale":"en_US"}}}`
+	template := `{"id":"by-locale","params":{"locale":"en_US"}}`
+	response := `{"took":3,"timed_out":false,"hits":{"total":{"value":1,"relation":"eq"},"max_score":0.2,"hits":[` +
+		`{"_index":"test-index","_id":"41","_score":0.2,"_source":{"locale":"en_US"},"highlight":{"locale":["<em>en_US</em>"]}}` +
+		`]}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		search        func(m *Manager) error
+		assert        func(m *Manager, body *godog.DocString) error
+		expected      string
+		expectedError string
+	}{
+		{
+			scenario:      "no search",
+			mock:          mockManager(),
+			search:        func(*Manager) error { return nil },
+			assert:        (*Manager).assertSearchResponse,
+			expectedError: `no search in the scenario`,
+		},
+		{
+			scenario: "search error",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(nil, errors.New("search error"))
+			}),
+			search: func(m *Manager) error {
+				return m.findDocuments(index, instance, &godog.DocString{Content: query})
+			},
+			assert:        (*Manager).assertSearchResponse,
+			expectedError: `search error`,
+		},
+		{
+			scenario: "not equal",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(response, nil)
+			}),
+			search: func(m *Manager) error {
+				return m.findDocuments(index, instance, &godog.DocString{Content: query})
+			},
+			assert:   (*Manager).assertSearchResponse,
+			expected: `{"hits":{"total":{"value":1,"relation":"eq"},"max_score":"<ignore-diff>","hits":"<ignore-diff>"}}`,
+			expectedError: `failed to compare search response: not equal:
+ {
+   "hits": {
+     "hits": "<ignore-diff>",
+     "max_score": "<ignore-diff>",
+     "total": {
+       "relation": "eq",
+       "value": 1
+     }
+   }
++  "timed_out": false
++  "took": 3
+ }
+`,
+		},
+		{
+			scenario: "equal",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(response, nil)
+			}),
+			search: func(m *Manager) error {
+				return m.findDocuments(index, instance, &godog.DocString{Content: query})
+			},
+			assert: (*Manager).assertSearchResponse,
+			expected: `{"took":"<ignore-diff>","timed_out":false,"hits":{"total":{"value":1,"relation":"eq"},"max_score":"<ignore-diff>","hits":[` +
+				`{"_index":"test-index","_id":"41","_score":"<ignore-diff>","_source":{"locale":"en_US"},"highlight":{"locale":["<em>en_US</em>"]}}` +
+				`]}}`,
+		},
+		{
+			scenario: "matches with template",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocumentsByTemplate", context.Background(), index, template).
+					Return(response, nil)
+			}),
+			search: func(m *Manager) error {
+				return m.findDocumentsWithTemplate(index, instance, &godog.DocString{Content: template})
+			},
+			assert:   (*Manager).assertSearchResponseMatches,
+			expected: `{"hits":{"hits":[{"_id":"41","highlight":{"locale":["<em>en_US</em>"]}}]}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.mock(t)
+
+			err := tc.search(m)
+			if err == nil {
+				err = tc.assert(m, &godog.DocString{Content: tc.expected})
+			}
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertSearchResponse_StoredResponse(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"locale":"en_US"}}}`
+	response := `{"hits":{"max_score":0.2,"hits":[{"_id":"41","_score":0.2,"_source":{"locale":"en_US"}}]}}`
+
+	// The search runs once, at the search step.
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &query).
+			Return(response, nil).Once()
+	})(t)
+
+	assert.NoError(t, m.findDocuments(index, instance, &godog.DocString{Content: query}))
+
+	assert.NoError(t, m.assertSearchResponse(&godog.DocString{Content: response}))
+	assert.NoError(t, m.assertSearchResponseMatches(&godog.DocString{Content: `{"hits":{"hits":[{"_id":"41"}]}}`}))
+	assert.NoError(t, m.assertFoundDocs(index, instance, &godog.DocString{Content: `[{"_id":"41","_score":0.2,"_source":{"locale":"en_US"}}]`}))
+	assert.NoError(t, m.assertSortedIDs(index, instance, "41"))
+	assert.NoError(t, m.assertMaxScore("less", 1))
+}
+
+func TestManager_assertNamedSearchDocs(t *testing.T) {
+	t.Parallel()
+
+	payload41 := json.RawMessage(`{"handle":"item-41","name":"Item 41","locale":"en_US","price":10}`)
+	payload42 := json.RawMessage(`{"handle":"item-42","name":"Item 42","locale":"en_US","price":100}`)
+
+	cheap := `{"query":{"range":{"price":{"lt":50}}}}`
+	expensive := `{"query":{"range":{"price":{"gte":50}}}}`
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &cheap).
+			Return(hitsResponse(payload41), nil)
+
+		c.On("SearchDocuments", context.Background(), index, &expensive).
+			Return(hitsResponse(payload42), nil)
+	})(t)
+
+	err := m.findDocumentsAs(index, instance, "cheap", &godog.DocString{Content: cheap})
+	assert.NoError(t, err)
+
+	err = m.findDocumentsAs(index, instance, "expensive", &godog.DocString{Content: expensive})
+	assert.NoError(t, err)
+
+	err = m.assertNamedSearchDocs("cheap", &godog.DocString{Content: fmt.Sprintf("[%s]", payload41)})
+	assert.NoError(t, err)
+
+	err = m.assertNamedSearchDocs("expensive", &godog.DocString{Content: fmt.Sprintf("[%s]", payload42)})
+	assert.NoError(t, err)
+
+	err = m.assertNamedSearchDocs("unknown", &godog.DocString{Content: `[]`})
+	assert.EqualError(t, err, `search "unknown" not found`)
+}
+
+func TestManager_assertNamedSearchDocsFromFile_NotFound(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+	err := m.assertNamedSearchDocsFromFile("cheap", &godog.DocString{Content: "unknown"})
+
+	expected := `could not read docs from file "unknown": open unknown: no such file or directory`
+
+	assert.EqualError(t, err, expected)
+}
+
+func TestManager_assertSortedIDs(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"name":"item"}}}`
+	response := `{"hits":{"max_score":2.5,"hits":[{"_id":"42","_score":2.5},{"_id":"41","_score":1.2},{"_id":"7","_score":0.3}]}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		ids           string
+		expectedError string
+	}{
+		{
+			scenario: "search error",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(nil, errors.New("search error"))
+			}),
+			ids:           "42, 41, 7",
+			expectedError: `search error`,
+		},
+		{
+			scenario: "different order",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(response, nil)
+			}),
+			ids:           "41, 42, 7",
+			expectedError: `expected ids in order 41, 42, 7, got 42, 41, 7`,
+		},
+		{
+			scenario: "missing id",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(response, nil)
+			}),
+			ids:           "42, 41",
+			expectedError: `expected ids in order 42, 41, got 42, 41, 7`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(response, nil)
+			}),
+			ids: `"42", 41,7`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := tc.mock(t)
+
+			err := m.findDocuments(index, instance, &godog.DocString{Content: query})
+			if err == nil {
+				err = m.assertSortedIDs(index, instance, tc.ids)
+			}
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertNamedSearchSortedIDs(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"name":"item"}},"sort":[{"_id":"asc"}]}`
+	response := `{"hits":{"max_score":null,"hits":[{"_id":"41","_score":null},{"_id":"42","_score":null}]}}`
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &query).
+			Return(response, nil)
+	})(t)
+
+	assert.NoError(t, m.findDocumentsAs(index, instance, "sorted", &godog.DocString{Content: query}))
+	assert.NoError(t, m.assertNamedSearchSortedIDs("sorted", "41, 42"))
+	assert.EqualError(t, m.assertNamedSearchSortedIDs("unknown", "41, 42"), `search "unknown" not found`)
+}
+
+func TestManager_assertScoresHigher(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"name":"item"}}}`
+	response := `{"hits":{"max_score":2.5,"hits":[{"_id":"42","_score":2.5},{"_id":"41","_score":1.2},{"_id":"7","_score":null}]}}`
+
+	testCases := []struct {
+		scenario      string
+		higher        string
+		lower         string
+		expectedError string
+	}{
+		{
+			scenario:      "not higher",
+			higher:        "41",
+			lower:         "42",
+			expectedError: `doc "41" scores 1.2, which is not higher than 2.5 of doc "42"`,
+		},
+		{
+			scenario:      "doc not found",
+			higher:        "42",
+			lower:         "43",
+			expectedError: `doc "43" is not found`,
+		},
+		{
+			scenario:      "no score",
+			higher:        "7",
+			lower:         "42",
+			expectedError: `doc "7" has no score`,
+		},
+		{
+			scenario: "success",
+			higher:   "42",
+			lower:    "41",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(response, nil)
+			})(t)
+
+			assert.NoError(t, m.findDocuments(index, instance, &godog.DocString{Content: query}))
+
+			err := m.assertScoresHigher(tc.higher, tc.lower)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertMaxScore(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"name":"item"}}}`
+
+	testCases := []struct {
+		scenario      string
+		response      string
+		comparison    string
+		score         float64
+		expectedError string
+	}{
+		{
+			scenario:      "no max score",
+			response:      `{"hits":{"max_score":null,"hits":[]}}`,
+			comparison:    "greater",
+			score:         1.5,
+			expectedError: `max score is not available`,
+		},
+		{
+			scenario:      "not greater",
+			response:      `{"hits":{"max_score":1.5,"hits":[]}}`,
+			comparison:    "greater",
+			score:         1.5,
+			expectedError: `max score 1.5 is not greater than 1.5`,
+		},
+		{
+			scenario:      "not less",
+			response:      `{"hits":{"max_score":2.5,"hits":[]}}`,
+			comparison:    "less",
+			score:         1.5,
+			expectedError: `max score 2.5 is not less than 1.5`,
+		},
+		{
+			scenario:   "greater",
+			response:   `{"hits":{"max_score":2.5,"hits":[]}}`,
+			comparison: "greater",
+			score:      1.5,
+		},
+		{
+			scenario:   "less",
+			response:   `{"hits":{"max_score":0.5,"hits":[]}}`,
+			comparison: "less",
+			score:      1.5,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			m := mockManager(func(c *client) {
+				c.On("SearchDocuments", context.Background(), index, &query).
+					Return(tc.response, nil)
+			})(t)
+
+			assert.NoError(t, m.findDocuments(index, instance, &godog.DocString{Content: query}))
+
+			err := m.assertMaxScore(tc.comparison, tc.score)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_assertNamedSearchScores(t *testing.T) {
+	t.Parallel()
+
+	red := `{"query":{"match":{"name":"red"}}}`
+	hat := `{"query":{"match":{"name":"hat"}}}`
+
+	m := mockManager(func(c *client) {
+		c.On("SearchDocuments", context.Background(), index, &red).
+			Return(`{"hits":{"max_score":2.5,"hits":[{"_id":"42","_score":2.5},{"_id":"41","_score":1.2}]}}`, nil).Once()
+
+		c.On("SearchDocuments", context.Background(), index, &hat).
+			Return(`{"hits":{"max_score":0.5,"hits":[{"_id":"43","_score":0.5}]}}`, nil).Once()
+	})(t)
+
+	assert.NoError(t, m.findDocumentsAs(index, instance, "red", &godog.DocString{Content: red}))
+	assert.NoError(t, m.findDocuments(index, instance, &godog.DocString{Content: hat}))
+
+	// The last search is not the named one.
+	assert.EqualError(t, m.assertScoresHigher("42", "41"), `doc "42" is not found`)
+	assert.NoError(t, m.assertMaxScore("less", 1))
+
+	assert.NoError(t, m.assertNamedSearchScoresHigher("42", "41", "red"))
+	assert.EqualError(t, m.assertNamedSearchScoresHigher("41", "42", "red"), `doc "41" scores 1.2, which is not higher than 2.5 of doc "42"`)
+	assert.NoError(t, m.assertNamedSearchMaxScore("red", "greater", 1))
+	assert.EqualError(t, m.assertNamedSearchMaxScore("red", "less", 1), `max score 2.5 is not less than 1`)
+
+	assert.EqualError(t, m.assertNamedSearchScoresHigher("42", "41", "unknown"), `search "unknown" not found`)
+	assert.EqualError(t, m.assertNamedSearchMaxScore("unknown", "greater", 1), `search "unknown" not found`)
+}
+
+func TestManager_assertMaxScore_NoSearch(t *testing.T) {
+	t.Parallel()
+
+	m := mockManager()(t)
+
+	assert.EqualError(t, m.assertMaxScore("greater", 1.5), `no search in the scenario`)
+	assert.EqualError(t, m.assertScoresHigher("42", "41"), `no search in the scenario`)
+}
+
 func TestManager_assertFoundDocsFromFile_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -620,6 +1724,65 @@ func TestManager_assertFoundDocsFromFile_NotFound(t *testing.T) {
 	assert.EqualError(t, err, expected)
 }
 
+func TestManager_UnknownInstance(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(mockClient()(t), WithInstance("extra", mockClient()(t)))
+
+	expected := `instance not found: es "extar", registered: _default, extra`
+
+	err := m.createIndex(index, "extar")
+
+	assert.ErrorIs(t, err, ErrInstanceNotFound)
+	assert.EqualError(t, err, expected)
+
+	assert.EqualError(t, m.findDocuments(index, "extar", &godog.DocString{Content: `{}`}), expected)
+	assert.EqualError(t, m.findDocumentsAs(index, "extar", "all", &godog.DocString{Content: `{}`}), expected)
+	assert.EqualError(t, m.assertNoDocs(index, "extar"), expected)
+	assert.EqualError(t, m.assertIndexNotExists(index, "extar"), expected)
+}
+
+func TestManager_DefaultInstanceName(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(
+		mockClient(func(c *client) {
+			c.On("CreateIndex", context.Background(), index, (*string)(nil)).
+				Return(nil).Twice()
+		})(t),
+		WithInstance("extra", mockClient()(t)),
+		WithDefaultInstanceName("main"),
+	)
+
+	assert.Equal(t, []string{"extra", "main"}, m.Instances())
+
+	assert.NoError(t, m.createIndex(index, "main"))
+	assert.NoError(t, m.createIndex(index, defaultInstance))
+}
+
+func TestManager_DefaultInstanceName_Search(t *testing.T) {
+	t.Parallel()
+
+	hit41 := json.RawMessage(`{"_id":"41","_source":{"name":"Item 41"}}`)
+	query := `{"query": {"match": {}}}`
+
+	m := NewManager(
+		mockClient(func(c *client) {
+			// The search runs once, the assertions check its response.
+			c.On("SearchDocuments", context.Background(), index, &query).
+				Return(hitsResponse(hit41), nil).Once()
+		})(t),
+		WithDefaultInstanceName("main"),
+	)
+
+	assert.NoError(t, m.findDocuments(index, "main", &godog.DocString{Content: query}))
+
+	// The search of the named default instance is the search of the default instance.
+	assert.NoError(t, m.assertFoundDocs(index, defaultInstance, &godog.DocString{Content: fmt.Sprintf("[%s]", hit41)}))
+	assert.NoError(t, m.assertFoundDocs(index, "main", &godog.DocString{Content: fmt.Sprintf("[%s]", hit41)}))
+	assert.NoError(t, m.assertSortedIDs(index, defaultInstance, "41"))
+}
+
 func mockManager(mocks ...func(c *client)) func(t *testing.T) *Manager {
 	return func(t *testing.T) *Manager {
 		t.Helper()
@@ -627,3 +1790,12 @@ func mockManager(mocks ...func(c *client)) func(t *testing.T) *Manager {
 		return NewManager(mockClient(mocks...)(t))
 	}
 }
+
+// hitsResponse returns a search response with the hits.
+func hitsResponse(hits ...json.RawMessage) string {
+	resp, _ := json.Marshal(map[string]interface{}{ // nolint: errcheck,errchkjson
+		"hits": map[string]interface{}{"hits": hits},
+	})
+
+	return string(resp)
+}
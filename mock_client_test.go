@@ -59,6 +59,10 @@ func (c *client) DeleteIndex(ctx context.Context, indices ...string) error {
 	return c.Called(args...).Error(0)
 }
 
+func (c *client) RefreshIndex(ctx context.Context, index string) error {
+	return c.Called(ctx, index).Error(0)
+}
+
 func (c *client) IndexDocuments(ctx context.Context, index string, documents ...Document) error {
 	i := 2
 	args := make([]interface{}, i+len(documents))
@@ -74,8 +78,60 @@ func (c *client) IndexDocuments(ctx context.Context, index string, documents ...
 }
 
 func (c *client) FindDocuments(ctx context.Context, index string, query *string) ([]json.RawMessage, error) {
-	results := c.Called(ctx, index, query)
+	return documents(c.Called(ctx, index, query))
+}
+
+func (c *client) StoreSearchTemplate(ctx context.Context, id string, source string) error {
+	return c.Called(ctx, id, source).Error(0)
+}
+
+func (c *client) SearchDocuments(ctx context.Context, index string, query *string) (json.RawMessage, error) {
+	return response(c.Called(ctx, index, query))
+}
+
+func (c *client) SearchDocumentsByTemplate(ctx context.Context, index string, template string) (json.RawMessage, error) {
+	return response(c.Called(ctx, index, template))
+}
+
+func (c *client) ExplainDocument(ctx context.Context, index string, id string, query string) (json.RawMessage, error) {
+	return response(c.Called(ctx, index, id, query))
+}
+
+func (c *client) Analyze(ctx context.Context, index string, text string, analyzer string) ([]string, error) {
+	return tokens(c.Called(ctx, index, text, analyzer))
+}
+
+func (c *client) AnalyzeField(ctx context.Context, index string, text string, field string) ([]string, error) {
+	return tokens(c.Called(ctx, index, text, field))
+}
+
+func tokens(results mock.Arguments) ([]string, error) {
+	result := results.Get(0)
+	err := results.Error(1)
+
+	if result == nil {
+		return nil, err
+	}
+
+	return result.([]string), err
+}
+
+func response(results mock.Arguments) (json.RawMessage, error) {
+	result := results.Get(0)
+	err := results.Error(1)
+
+	switch r := result.(type) {
+	case nil:
+		return nil, err
+
+	case string:
+		return json.RawMessage(r), err
+	}
+
+	return result.(json.RawMessage), err
+}
 
+func documents(results mock.Arguments) ([]json.RawMessage, error) {
 	result := results.Get(0)
 	err := results.Error(1)
 
@@ -118,6 +174,75 @@ func (c *client) DeleteAllDocuments(ctx context.Context, index string) error {
 	return c.Called(ctx, index).Error(0)
 }
 
+func (c *client) DeleteDocument(ctx context.Context, index string, id string) error {
+	return c.Called(ctx, index, id).Error(0)
+}
+
+func (c *client) DeleteDocumentsByQuery(ctx context.Context, index string, query string) error {
+	return c.Called(ctx, index, query).Error(0)
+}
+
+func (c *client) UpdateDocument(ctx context.Context, index string, id string, update string) error {
+	return c.Called(ctx, index, id, update).Error(0)
+}
+
+func (c *client) UpdateDocumentsByQuery(ctx context.Context, index string, body string) error {
+	return c.Called(ctx, index, body).Error(0)
+}
+
+func (c *client) Reindex(ctx context.Context, source, dest string, body *string) error {
+	return c.Called(ctx, source, dest, body).Error(0)
+}
+
+func (c *client) ClusterHealth(ctx context.Context) (string, error) {
+	results := c.Called(ctx)
+
+	return results.String(0), results.Error(1)
+}
+
+func (c *client) CreateSnapshotRepository(ctx context.Context, repository, location string) error {
+	return c.Called(ctx, repository, location).Error(0)
+}
+
+func (c *client) CreateSnapshot(ctx context.Context, repository, snapshot string, indices ...string) error {
+	return c.Called(ctx, repository, snapshot, indices).Error(0)
+}
+
+func (c *client) RestoreSnapshot(ctx context.Context, repository, snapshot string, indices ...string) error {
+	return c.Called(ctx, repository, snapshot, indices).Error(0)
+}
+
+func (c *client) PutLifecyclePolicy(ctx context.Context, policy, body string) error {
+	return c.Called(ctx, policy, body).Error(0)
+}
+
+func (c *client) SetLifecyclePolicy(ctx context.Context, index, policy string) error {
+	return c.Called(ctx, index, policy).Error(0)
+}
+
+func (c *client) Rollover(ctx context.Context, alias string) error {
+	return c.Called(ctx, alias).Error(0)
+}
+
+func (c *client) ExplainLifecycle(ctx context.Context, index string) (LifecycleState, error) {
+	results := c.Called(ctx, index)
+
+	return results.Get(0).(LifecycleState), results.Error(1)
+}
+
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e statusError) Error() string {
+	return e.message
+}
+
+func (e statusError) StatusCode() int {
+	return e.code
+}
+
 // mockClient creates Client mock with cleanup to ensure all the expectations are met.
 func mockClient(mocks ...func(c *client)) clientMocker {
 	return func(tb testing.TB) *client {
@@ -0,0 +1,177 @@
+package elasticsteps
+
+import "fmt"
+
+// notSupported is the error of a step that needs an optional interface the client of the instance does not implement.
+func (m *Manager) notSupported(instance, iface string) error {
+	if instance == defaultInstance {
+		instance = m.defaultInstanceName
+	}
+
+	return fmt.Errorf("%w: %s %q does not implement elasticsteps.%s", ErrNotSupported, m.vocabulary.noun(), instance, iface)
+}
+
+// nolint: ireturn
+func (m *Manager) searcher(instance string) (DocumentSearcher, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := c.(DocumentSearcher)
+	if !ok {
+		return nil, m.notSupported(instance, "DocumentSearcher")
+	}
+
+	return s, nil
+}
+
+// nolint: ireturn
+func (m *Manager) remover(instance string) (DocumentRemover, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := c.(DocumentRemover)
+	if !ok {
+		return nil, m.notSupported(instance, "DocumentRemover")
+	}
+
+	return r, nil
+}
+
+// nolint: ireturn
+func (m *Manager) updater(instance string) (DocumentUpdater, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := c.(DocumentUpdater)
+	if !ok {
+		return nil, m.notSupported(instance, "DocumentUpdater")
+	}
+
+	return u, nil
+}
+
+// nolint: ireturn
+func (m *Manager) explainer(instance string) (DocumentExplainer, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	e, ok := c.(DocumentExplainer)
+	if !ok {
+		return nil, m.notSupported(instance, "DocumentExplainer")
+	}
+
+	return e, nil
+}
+
+// nolint: ireturn
+func (m *Manager) analyzer(instance string) (Analyzer, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := c.(Analyzer)
+	if !ok {
+		return nil, m.notSupported(instance, "Analyzer")
+	}
+
+	return a, nil
+}
+
+// nolint: ireturn
+func (m *Manager) templateStorer(instance string) (SearchTemplateStorer, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := c.(SearchTemplateStorer)
+	if !ok {
+		return nil, m.notSupported(instance, "SearchTemplateStorer")
+	}
+
+	return s, nil
+}
+
+// nolint: ireturn
+func (m *Manager) refresher(instance string) (IndexRefresher, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := c.(IndexRefresher)
+	if !ok {
+		return nil, m.notSupported(instance, "IndexRefresher")
+	}
+
+	return r, nil
+}
+
+// nolint: ireturn
+func (m *Manager) reindexer(instance string) (IndexReindexer, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := c.(IndexReindexer)
+	if !ok {
+		return nil, m.notSupported(instance, "IndexReindexer")
+	}
+
+	return r, nil
+}
+
+// nolint: ireturn
+func (m *Manager) healthChecker(instance string) (HealthChecker, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	h, ok := c.(HealthChecker)
+	if !ok {
+		return nil, m.notSupported(instance, "HealthChecker")
+	}
+
+	return h, nil
+}
+
+// nolint: ireturn
+func (m *Manager) snapshotManager(instance string) (SnapshotManager, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := c.(SnapshotManager)
+	if !ok {
+		return nil, m.notSupported(instance, "SnapshotManager")
+	}
+
+	return s, nil
+}
+
+// nolint: ireturn
+func (m *Manager) lifecycleManager(instance string) (LifecycleManager, error) {
+	c, err := m.client(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	l, ok := c.(LifecycleManager)
+	if !ok {
+		return nil, m.notSupported(instance, "LifecycleManager")
+	}
+
+	return l, nil
+}
@@ -0,0 +1,49 @@
+package elasticsteps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+)
+
+// baseClient only implements Client, like the drivers written before the optional interfaces.
+type baseClient struct {
+	Client
+}
+
+func TestManager_BaseClient(t *testing.T) {
+	t.Parallel()
+
+	query := `{"query":{"match":{"name":"foo"}}}`
+
+	c := mockClient(func(c *client) {
+		c.On("FindDocuments", context.Background(), "products", &query).
+			Return([]string{`{"_id":"41","_source":{"name":"foo"}}`}, nil).Once()
+
+		c.On("FindDocuments", context.Background(), "products", (*string)(nil)).
+			Return([]string{`{"_id":"41","_source":{"name":"foo"}}`, `{"_id":"42","_source":{"name":"bar"}}`}, nil).Once()
+	})(t)
+
+	m := NewManager(baseClient{c})
+
+	// The searches fall back to DocumentFinder.
+	assert.NoError(t, m.findDocuments("products", defaultInstance, &godog.DocString{Content: query}))
+	assert.NoError(t, m.assertFoundDocs("products", defaultInstance, &godog.DocString{Content: `[{"_id":"41","_source":{"name":"foo"}}]`}))
+	assert.NoError(t, m.assertNumDocs(2, "products", defaultInstance))
+
+	// The other steps need the optional interfaces.
+	err := m.refreshIndex("products", defaultInstance)
+
+	assert.ErrorIs(t, err, ErrNotSupported)
+	assert.EqualError(t, err, `not supported: es "_default" does not implement elasticsteps.IndexRefresher`)
+
+	err = m.findDocumentsWithTemplate("products", defaultInstance, &godog.DocString{Content: `{"id":"by-name"}`})
+
+	assert.EqualError(t, err, `not supported: es "_default" does not implement elasticsteps.DocumentSearcher`)
+
+	err = m.deleteDoc("41", "products", defaultInstance)
+
+	assert.EqualError(t, err, `not supported: es "_default" does not implement elasticsteps.DocumentRemover`)
+}
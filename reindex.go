@@ -0,0 +1,27 @@
+package elasticsteps
+
+import "github.com/cucumber/godog"
+
+func (m *Manager) reindex(source, dest, instance string, body *godog.DocString) error {
+	c, err := m.reindexer(instance)
+	if err != nil {
+		return err
+	}
+
+	var req *string
+
+	if body != nil {
+		req = &body.Content
+	}
+
+	return c.Reindex(m.ctx(), source, dest, req)
+}
+
+func (m *Manager) updateDocsByQuery(index, instance string, body *godog.DocString) error {
+	c, err := m.updater(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateDocumentsByQuery(m.ctx(), index, body.Content)
+}
@@ -0,0 +1,126 @@
+package elasticsteps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_reindex(t *testing.T) {
+	t.Parallel()
+
+	body := `{"query":{"term":{"locale":"en_US"}}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		instance      string
+		body          *godog.DocString
+		expectedError string
+	}{
+		{
+			scenario: "unknown instance",
+			mock: mockManager(func(*client) {
+			}),
+			instance:      "extra",
+			expectedError: `instance not found: es "extra", registered: _default`,
+		},
+		{
+			scenario: "could not reindex",
+			mock: mockManager(func(c *client) {
+				c.On("Reindex", context.Background(), index, "dest-index", (*string)(nil)).
+					Return(errors.New("reindex error"))
+			}),
+			instance:      instance,
+			expectedError: `reindex error`,
+		},
+		{
+			scenario: "without body",
+			mock: mockManager(func(c *client) {
+				c.On("Reindex", context.Background(), index, "dest-index", (*string)(nil)).
+					Return(nil)
+			}),
+			instance: instance,
+		},
+		{
+			scenario: "with body",
+			mock: mockManager(func(c *client) {
+				c.On("Reindex", context.Background(), index, "dest-index", &body).
+					Return(nil)
+			}),
+			instance: instance,
+			body:     &godog.DocString{Content: body},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).reindex(index, "dest-index", tc.instance, tc.body)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_updateDocsByQuery(t *testing.T) {
+	t.Parallel()
+
+	body := `{"query":{"match_all":{}},"script":{"source":"ctx._source.locale = 'fr_FR'"}}`
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		instance      string
+		expectedError string
+	}{
+		{
+			scenario: "unknown instance",
+			mock: mockManager(func(*client) {
+			}),
+			instance:      "extra",
+			expectedError: `instance not found: es "extra", registered: _default`,
+		},
+		{
+			scenario: "could not update",
+			mock: mockManager(func(c *client) {
+				c.On("UpdateDocumentsByQuery", context.Background(), index, body).
+					Return(errors.New("update error"))
+			}),
+			instance:      instance,
+			expectedError: `update error`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("UpdateDocumentsByQuery", context.Background(), index, body).
+					Return(nil)
+			}),
+			instance: instance,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).updateDocsByQuery(index, tc.instance, &godog.DocString{Content: body})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
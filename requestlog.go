@@ -0,0 +1,109 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+type requestLogKey struct{}
+
+// RequestLogEntry is a request to es and its response.
+type RequestLogEntry struct {
+	Method      string
+	URL         string
+	RequestBody string
+	Status      int
+	// ResponseBody is only recorded for the failed requests.
+	ResponseBody string
+	Duration     time.Duration
+	Err          error
+}
+
+// RequestLog records the requests to es. The drivers record the requests when their context carries a log, see
+// ContextWithRequestLog().
+type RequestLog struct {
+	mu      sync.Mutex
+	entries []RequestLogEntry
+}
+
+// Add records a request.
+func (l *RequestLog) Add(e RequestLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries = append(l.entries, e)
+}
+
+// Entries returns the recorded requests.
+func (l *RequestLog) Entries() []RequestLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]RequestLogEntry(nil), l.entries...)
+}
+
+// WriteTo writes the recorded requests, the request bodies are prefixed with > and the response bodies with <.
+func (l *RequestLog) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	for _, e := range l.Entries() {
+		if e.Err != nil {
+			fmt.Fprintf(&buf, "%s %s error: %s (%s)\n", e.Method, e.URL, e.Err, e.Duration)
+		} else {
+			fmt.Fprintf(&buf, "%s %s %d (%s)\n", e.Method, e.URL, e.Status, e.Duration)
+		}
+
+		writeBody(&buf, "> ", e.RequestBody)
+		writeBody(&buf, "< ", e.ResponseBody)
+	}
+
+	return buf.WriteTo(w)
+}
+
+func writeBody(buf *bytes.Buffer, prefix, body string) {
+	if body = strings.TrimSpace(body); body == "" {
+		return
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		buf.WriteString(prefix + line + "\n")
+	}
+}
+
+// ContextWithRequestLog returns a context that carries the request log.
+func ContextWithRequestLog(ctx context.Context, l *RequestLog) context.Context {
+	return context.WithValue(ctx, requestLogKey{}, l)
+}
+
+// RequestLogFromContext returns the request log of the context, if any.
+func RequestLogFromContext(ctx context.Context) *RequestLog {
+	l, _ := ctx.Value(requestLogKey{}).(*RequestLog) // nolint: errcheck
+
+	return l
+}
+
+// ctx returns the context of the requests to es, it carries the span of the current step and the request log.
+func (m *Manager) ctx() context.Context {
+	ctx := m.stepCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if m.requestLog == nil {
+		return ctx
+	}
+
+	return ContextWithRequestLog(ctx, m.requestLog)
+}
+
+// WithRequestLog records the requests to es during each scenario, and writes them to w when the scenario fails.
+func WithRequestLog(w io.Writer) ManagerOption {
+	return func(m *Manager) {
+		m.requestLogOutput = w
+	}
+}
@@ -0,0 +1,61 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLog_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	l := &RequestLog{}
+
+	l.Add(RequestLogEntry{
+		Method:       "PUT",
+		URL:          "/products",
+		RequestBody:  "{\n    \"mappings\": {}\n}",
+		Status:       400,
+		ResponseBody: `{"error":{"type":"resource_already_exists_exception"},"status":400}`,
+		Duration:     12 * time.Millisecond,
+	})
+
+	l.Add(RequestLogEntry{
+		Method:   "GET",
+		URL:      "/products/_search",
+		Err:      errors.New("connection refused"),
+		Duration: time.Millisecond,
+	})
+
+	expected := `PUT /products 400 (12ms)
+> {
+>     "mappings": {}
+> }
+< {"error":{"type":"resource_already_exists_exception"},"status":400}
+GET /products/_search error: connection refused (1ms)
+`
+
+	var buf bytes.Buffer
+
+	_, err := l.WriteTo(&buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestManager_ctx(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(nil, WithRequestLog(&bytes.Buffer{}))
+
+	assert.Nil(t, RequestLogFromContext(m.ctx()))
+
+	m.requestLog = &RequestLog{}
+
+	assert.Same(t, m.requestLog, RequestLogFromContext(m.ctx()))
+	assert.Nil(t, RequestLogFromContext(context.Background()))
+}
@@ -0,0 +1,31 @@
+package elasticsteps
+
+// DefaultSnapshotRepository is the repository of the snapshot steps that do not name one.
+const DefaultSnapshotRepository = "elasticsteps"
+
+func (m *Manager) createSnapshotRepository(repository, location, instance string) error {
+	c, err := m.snapshotManager(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.CreateSnapshotRepository(m.ctx(), repository, location)
+}
+
+func (m *Manager) createSnapshot(snapshot, indices, repository, instance string) error {
+	c, err := m.snapshotManager(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.CreateSnapshot(m.ctx(), repository, snapshot, splitList(indices)...)
+}
+
+func (m *Manager) restoreSnapshot(indices, snapshot, repository, instance string) error {
+	c, err := m.snapshotManager(instance)
+	if err != nil {
+		return err
+	}
+
+	return c.RestoreSnapshot(m.ctx(), repository, snapshot, splitList(indices)...)
+}
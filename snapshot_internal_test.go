@@ -0,0 +1,111 @@
+package elasticsteps
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_createSnapshot(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		instance      string
+		expectedError string
+	}{
+		{
+			scenario: "unknown instance",
+			mock: mockManager(func(*client) {
+			}),
+			instance:      "extra",
+			expectedError: `instance not found: es "extra", registered: _default`,
+		},
+		{
+			scenario: "could not create snapshot",
+			mock: mockManager(func(c *client) {
+				c.On("CreateSnapshot", context.Background(), DefaultSnapshotRepository, "seed", []string{index, "other-index"}).
+					Return(errors.New("create error"))
+			}),
+			instance:      instance,
+			expectedError: `create error`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("CreateSnapshot", context.Background(), DefaultSnapshotRepository, "seed", []string{index, "other-index"}).
+					Return(nil)
+			}),
+			instance: instance,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).createSnapshot("seed", index+", other-index", DefaultSnapshotRepository, tc.instance)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManager_restoreSnapshot(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mock          managerMocker
+		instance      string
+		expectedError string
+	}{
+		{
+			scenario: "unknown instance",
+			mock: mockManager(func(*client) {
+			}),
+			instance:      "extra",
+			expectedError: `instance not found: es "extra", registered: _default`,
+		},
+		{
+			scenario: "could not restore snapshot",
+			mock: mockManager(func(c *client) {
+				c.On("RestoreSnapshot", context.Background(), "fixtures", "seed", []string{index}).
+					Return(errors.New("restore error"))
+			}),
+			instance:      instance,
+			expectedError: `restore error`,
+		},
+		{
+			scenario: "success",
+			mock: mockManager(func(c *client) {
+				c.On("RestoreSnapshot", context.Background(), "fixtures", "seed", []string{index}).
+					Return(nil)
+			}),
+			instance: instance,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mock(t).restoreSnapshot(index, "seed", "fixtures", tc.instance)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
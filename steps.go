@@ -0,0 +1,253 @@
+package elasticsteps
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/cucumber/godog"
+)
+
+// StepKind is the kind of step: a prerequisite (Given), an action (When) or an assertion (Then).
+type StepKind string
+
+const (
+	// StepPrerequisite sets up the indices and the docs.
+	StepPrerequisite StepKind = "prerequisite"
+	// StepAction runs searches and changes the docs.
+	StepAction StepKind = "action"
+	// StepAssertion checks the indices, the docs and the search results.
+	StepAssertion StepKind = "assertion"
+)
+
+// StepDefinition describes a step registered by the manager.
+type StepDefinition struct {
+	Kind      StepKind       `json:"kind"`
+	Pattern   string         `json:"pattern"`
+	Arguments []StepArgument `json:"arguments,omitempty"`
+	DocString bool           `json:"docString,omitempty"`
+	Table     bool           `json:"table,omitempty"`
+	Example   string         `json:"example"`
+}
+
+// StepArgument describes an argument captured by a step pattern.
+type StepArgument struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// stepRegistrar registers the steps of the manager.
+type stepRegistrar interface {
+	Step(expr string, args stepArgs, stepFunc interface{})
+}
+
+// stepArgs names the arguments captured by the default pattern of a step, in order.
+type stepArgs []string
+
+const optionalColon = `[:]?`
+
+var (
+	docStringType = reflect.TypeOf((*godog.DocString)(nil))
+	tableType     = reflect.TypeOf((*godog.Table)(nil))
+
+	optionalGroup     = regexp.MustCompile(`\(\?:[^()]*\)\?`)
+	alternativeGroup  = regexp.MustCompile(`\(\?:(?:[^()|]*\|)*([^()|]*)\)`)
+	optionalCharacter = regexp.MustCompile(`(\w)\?`)
+	escapedCharacter  = regexp.MustCompile(`\\(\W)`)
+)
+
+var argumentTypes = map[reflect.Kind]string{
+	reflect.String:  "string",
+	reflect.Int:     "int",
+	reflect.Int64:   "int",
+	reflect.Float64: "float",
+}
+
+var stepHeadings = map[StepKind]string{
+	StepPrerequisite: "Prerequisites",
+	StepAction:       "Actions",
+	StepAssertion:    "Assertions",
+}
+
+var stepKeywords = map[StepKind]string{
+	StepPrerequisite: "Given",
+	StepAction:       "When",
+	StepAssertion:    "Then",
+}
+
+type stepRecorder struct {
+	kind       StepKind
+	vocabulary vocabulary
+	steps      []StepDefinition
+}
+
+func (r *stepRecorder) Step(expr string, args stepArgs, stepFunc interface{}) {
+	fn := reflect.TypeOf(stepFunc)
+
+	step := StepDefinition{Kind: r.kind, Pattern: r.vocabulary.pattern(expr)}
+	groups := captureGroups(step.Pattern)
+
+	for i := range groups {
+		name := fmt.Sprintf("arg%d", i+1)
+
+		// The names are given with the default pattern, the rewritten one could be in another language.
+		if i < len(args) {
+			name = args[i]
+		}
+
+		step.Arguments = append(step.Arguments, StepArgument{Name: name, Type: argumentTypes[fn.In(i).Kind()]})
+	}
+
+	for i := len(groups); i < fn.NumIn(); i++ {
+		switch fn.In(i) {
+		case docStringType:
+			step.DocString = true
+
+		case tableType:
+			step.Table = true
+		}
+	}
+
+	step.Example = example(step, groups)
+	r.steps = append(r.steps, step)
+}
+
+// Steps returns the definitions of the steps registered by the manager, in the order of registration. The patterns
+// are rewritten by the step options, like WithStepPrefix() or WithInstanceNoun().
+func (m *Manager) Steps() []StepDefinition {
+	r := &stepRecorder{vocabulary: m.vocabulary}
+
+	r.kind = StepPrerequisite
+	m.registerPrerequisites(r)
+
+	r.kind = StepAction
+	m.registerActions(r)
+
+	r.kind = StepAssertion
+	m.registerAssertions(r)
+
+	return r.steps
+}
+
+// captureGroups returns the start and the end of the capturing groups of a pattern.
+func captureGroups(pattern string) [][2]int {
+	var (
+		groups [][2]int
+		stack  []int
+	)
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+
+		case '(':
+			stack = append(stack, i)
+
+		case ')':
+			start := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !strings.HasPrefix(pattern[start:], "(?") && len(stack) == 0 {
+				groups = append(groups, [2]int{start, i + 1})
+			}
+		}
+	}
+
+	return groups
+}
+
+func example(step StepDefinition, groups [][2]int) string {
+	var sb strings.Builder
+
+	prev := 0
+
+	for i, g := range groups {
+		sb.WriteString(pattern(step.Pattern[prev:g[0]], step))
+		sb.WriteString("<" + step.Arguments[i].Name + ">")
+
+		prev = g[1]
+	}
+
+	sb.WriteString(pattern(step.Pattern[prev:], step))
+
+	return stepKeywords[step.Kind] + " " + sb.String()
+}
+
+// pattern turns a part of a step pattern without capturing groups into text.
+func pattern(s string, step StepDefinition) string {
+	colon := ""
+	if step.DocString || step.Table {
+		colon = ":"
+	}
+
+	s = strings.TrimPrefix(s, "^")
+	s = strings.TrimSuffix(s, "$")
+	s = strings.ReplaceAll(s, optionalColon, colon)
+	s = optionalGroup.ReplaceAllString(s, "")
+	s = alternativeGroup.ReplaceAllString(s, "$1")
+	s = optionalCharacter.ReplaceAllString(s, "$1")
+	s = escapedCharacter.ReplaceAllString(s, "$1")
+
+	return s
+}
+
+// WriteStepsJSON writes the step definitions as JSON.
+func WriteStepsJSON(w io.Writer, steps []StepDefinition) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+
+	return enc.Encode(steps)
+}
+
+// WriteStepsMarkdown writes the step definitions as Markdown tables, one table per kind of step.
+func WriteStepsMarkdown(w io.Writer, steps []StepDefinition) error {
+	var sb strings.Builder
+
+	for _, kind := range []StepKind{StepPrerequisite, StepAction, StepAssertion} {
+		sb.WriteString("## " + stepHeadings[kind] + "\n\n")
+		sb.WriteString("| Step | Arguments | Body | Example |\n")
+		sb.WriteString("| :--- | :--- | :---: | :--- |\n")
+
+		for _, step := range steps {
+			if step.Kind != kind {
+				continue
+			}
+
+			args := make([]string, 0, len(step.Arguments))
+
+			for _, arg := range step.Arguments {
+				args = append(args, fmt.Sprintf("`%s` (%s)", arg.Name, arg.Type))
+			}
+
+			body := ""
+
+			switch {
+			case step.DocString:
+				body = "DocString"
+
+			case step.Table:
+				body = "Table"
+			}
+
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+				markdownCode(step.Pattern), strings.Join(args, ", "), body, markdownCode(step.Example),
+			)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// markdownCode formats a code span that could be used in a table.
+func markdownCode(s string) string {
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
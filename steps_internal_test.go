@@ -0,0 +1,178 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Steps(t *testing.T) {
+	t.Parallel()
+
+	steps := NewManager(nil).Steps()
+
+	for _, step := range steps {
+		for _, arg := range step.Arguments {
+			assert.False(t, strings.HasPrefix(arg.Name, "arg"), "argument %q of step %q is not named", arg.Name, step.Pattern)
+			assert.NotEmpty(t, arg.Type, "argument %q of step %q has no type", arg.Name, step.Pattern)
+		}
+	}
+
+	expected := []StepDefinition{
+		{
+			Kind:    StepPrerequisite,
+			Pattern: `index "([^"]*)" is created in es "([^"]*)"$`,
+			Arguments: []StepArgument{
+				{Name: "index", Type: "string"},
+				{Name: "instance", Type: "string"},
+			},
+			Example: `Given index "<index>" is created in es "<instance>"`,
+		},
+		{
+			Kind:      StepPrerequisite,
+			Pattern:   `docs (?:in|from) this file are stored in index "([^"]*)"[:]?$`,
+			Arguments: []StepArgument{{Name: "index", Type: "string"}},
+			DocString: true,
+			Example:   `Given docs from this file are stored in index "<index>":`,
+		},
+		{
+			Kind:    StepAssertion,
+			Pattern: `(\d+) docs? (?:is|are) available in index "([^"]*)"$`,
+			Arguments: []StepArgument{
+				{Name: "count", Type: "int"},
+				{Name: "index", Type: "string"},
+			},
+			Example: `Then <count> docs are available in index "<index>"`,
+		},
+		{
+			Kind:    StepAssertion,
+			Pattern: `max score is (greater|less) than (-?\d+(?:\.\d+)?)$`,
+			Arguments: []StepArgument{
+				{Name: "comparison", Type: "string"},
+				{Name: "score", Type: "float"},
+			},
+			Example: `Then max score is <comparison> than <score>`,
+		},
+		{
+			Kind:    StepAssertion,
+			Pattern: `doc "([^"]*)" scores higher than doc "([^"]*)"$`,
+			Arguments: []StepArgument{
+				{Name: "higher", Type: "string"},
+				{Name: "lower", Type: "string"},
+			},
+			Example: `Then doc "<higher>" scores higher than doc "<lower>"`,
+		},
+	}
+
+	for _, e := range expected {
+		assert.Contains(t, steps, e)
+	}
+}
+
+// argsChecker checks that the arguments of the steps are all named.
+type argsChecker struct {
+	t *testing.T
+}
+
+func (c argsChecker) Step(expr string, args stepArgs, _ interface{}) {
+	assert.Len(c.t, args, len(captureGroups(expr)), "the arguments of step %q are not named", expr)
+}
+
+func TestManager_Steps_ArgumentNames(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(nil)
+	r := argsChecker{t: t}
+
+	m.registerPrerequisites(r)
+	m.registerActions(r)
+	m.registerAssertions(r)
+}
+
+func TestStepsFile(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteStepsMarkdown(&buf, NewManager(nil).Steps()))
+
+	actual, err := os.ReadFile("STEPS.md")
+	require.NoError(t, err)
+
+	assert.Equal(t, buf.String(), string(actual), "STEPS.md is outdated, run `make steps`")
+}
+
+func TestWriteStepsMarkdown(t *testing.T) {
+	t.Parallel()
+
+	steps := []StepDefinition{
+		{
+			Kind:      StepPrerequisite,
+			Pattern:   `docs (?:in|from) this file are stored in index "([^"]*)"[:]?$`,
+			Arguments: []StepArgument{{Name: "index", Type: "string"}},
+			DocString: true,
+			Example:   `Given docs from this file are stored in index "<index>":`,
+		},
+		{
+			Kind:    StepAssertion,
+			Pattern: `index "([^"]*)" exists$`,
+			Arguments: []StepArgument{
+				{Name: "index", Type: "string"},
+			},
+			Example: `Then index "<index>" exists`,
+		},
+	}
+
+	expected := "## Prerequisites\n\n" +
+		"| Step | Arguments | Body | Example |\n" +
+		"| :--- | :--- | :---: | :--- |\n" +
+		"| `docs (?:in\\|from) this file are stored in index \"([^\"]*)\"[:]?$` | `index` (string) | DocString | `Given docs from this file are stored in index \"<index>\":` |\n" +
+		"\n" +
+		"## Actions\n\n" +
+		"| Step | Arguments | Body | Example |\n" +
+		"| :--- | :--- | :---: | :--- |\n" +
+		"\n" +
+		"## Assertions\n\n" +
+		"| Step | Arguments | Body | Example |\n" +
+		"| :--- | :--- | :---: | :--- |\n" +
+		"| `index \"([^\"]*)\" exists$` | `index` (string) |  | `Then index \"<index>\" exists` |\n" +
+		"\n"
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, WriteStepsMarkdown(&buf, steps))
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteStepsJSON(t *testing.T) {
+	t.Parallel()
+
+	steps := []StepDefinition{
+		{
+			Kind:      StepAction,
+			Pattern:   `I search in index "([^"]*)" with query[:]?$`,
+			Arguments: []StepArgument{{Name: "index", Type: "string"}},
+			DocString: true,
+			Example:   `When I search in index "<index>" with query:`,
+		},
+	}
+
+	expected := `[
+		{
+			"kind": "action",
+			"pattern": "I search in index \"([^\"]*)\" with query[:]?$",
+			"arguments": [{"name": "index", "type": "string"}],
+			"docString": true,
+			"example": "When I search in index \"<index>\" with query:"
+		}
+	]`
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, WriteStepsJSON(&buf, steps))
+	assert.JSONEq(t, expected, buf.String())
+}
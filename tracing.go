@@ -0,0 +1,67 @@
+package elasticsteps
+
+import (
+	"context"
+
+	"github.com/cucumber/godog"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/godogx/elasticsteps"
+
+// registerTracing starts a span for each scenario and each step. The steps run the requests to es with the context of
+// the current step, so the spans of the drivers are nested in the span of the step.
+func (m *Manager) registerTracing(sc *godog.ScenarioContext) {
+	tracer := m.tracerProvider.Tracer(tracerName)
+
+	var scenarioSpan trace.Span
+
+	sc.Before(func(ctx context.Context, s *godog.Scenario) (context.Context, error) {
+		ctx, scenarioSpan = tracer.Start(ctx, "scenario: "+s.Name)
+		m.stepCtx = ctx
+
+		return ctx, nil
+	})
+
+	sc.StepContext().Before(func(ctx context.Context, st *godog.Step) (context.Context, error) {
+		ctx, _ = tracer.Start(ctx, "step: "+st.Text)
+		m.stepCtx = ctx
+
+		return ctx, nil
+	})
+
+	sc.StepContext().After(func(ctx context.Context, _ *godog.Step, _ godog.StepResultStatus, err error) (context.Context, error) {
+		endSpan(trace.SpanFromContext(ctx), err)
+
+		// The next step is a sibling, not a child, of this one.
+		ctx = trace.ContextWithSpan(ctx, scenarioSpan)
+		m.stepCtx = ctx
+
+		return ctx, nil
+	})
+
+	sc.After(func(ctx context.Context, _ *godog.Scenario, err error) (context.Context, error) {
+		endSpan(scenarioSpan, err)
+
+		m.stepCtx = nil
+
+		return ctx, nil
+	})
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// WithTracerProvider traces the scenarios, the steps and the requests to es with OpenTelemetry.
+func WithTracerProvider(tp trace.TracerProvider) ManagerOption {
+	return func(m *Manager) {
+		m.tracerProvider = tp
+	}
+}
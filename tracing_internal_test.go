@@ -0,0 +1,74 @@
+package elasticsteps
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestManager_WithTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	m := NewManager(
+		mockClient(func(c *client) {
+			c.On("CreateIndex", mock.Anything, index, (*string)(nil)).
+				Run(func(args mock.Arguments) {
+					// The requests are run with the span of the step.
+					assert.True(t, trace.SpanContextFromContext(args.Get(0).(context.Context)).IsValid())
+				}).
+				Return(nil)
+
+			c.On("GetIndex", mock.Anything, index).
+				Return(nil, ErrIndexNotFound)
+		})(t),
+		WithTracerProvider(tp),
+	)
+
+	feature := `Feature: Tracing
+    Scenario: Create index
+        Given index "test-index" is created
+        Then index "test-index" exists
+`
+
+	status := godog.TestSuite{
+		ScenarioInitializer: m.RegisterContext,
+		Options: &godog.Options{
+			Format:          "progress",
+			Output:          &bytes.Buffer{},
+			FeatureContents: []godog.Feature{{Name: "tracing.feature", Contents: []byte(feature)}},
+		},
+	}.Run()
+
+	assert.Equal(t, 1, status)
+
+	spans := recorder.Ended()
+
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, `step: index "test-index" is created`, spans[0].Name())
+	assert.Equal(t, `step: index "test-index" exists`, spans[1].Name())
+	assert.Equal(t, `scenario: Create index`, spans[2].Name())
+
+	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[1].Parent().SpanID())
+
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Equal(t, "index not found", spans[2].Status().Description)
+
+	// The context of the requests is reset after the scenario.
+	assert.Nil(t, m.stepCtx)
+}
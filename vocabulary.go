@@ -0,0 +1,86 @@
+package elasticsteps
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cucumber/godog"
+)
+
+// instanceNoun is the noun of the es instance in the default patterns, as in `in es "..."` or `es is healthy`.
+var instanceNoun = regexp.MustCompile(`(^|\s)es\s`)
+
+// vocabulary rewrites the default step patterns.
+type vocabulary struct {
+	prefix       string
+	instanceNoun string
+	translations map[string]string
+}
+
+// noun returns the noun of the es instances, for the messages.
+func (v vocabulary) noun() string {
+	if v.instanceNoun != "" {
+		return v.instanceNoun
+	}
+
+	return "es"
+}
+
+func (v vocabulary) pattern(pattern string) string {
+	if translated, ok := v.translations[pattern]; ok {
+		pattern = translated
+	} else if v.instanceNoun != "" {
+		pattern = instanceNoun.ReplaceAllStringFunc(pattern, func(s string) string {
+			return strings.Replace(s, "es", regexp.QuoteMeta(v.instanceNoun), 1)
+		})
+	}
+
+	if v.prefix != "" {
+		pattern = "^" + regexp.QuoteMeta(v.prefix) + strings.TrimPrefix(pattern, "^")
+	}
+
+	return pattern
+}
+
+// vocabularyRegistrar registers the steps with the rewritten patterns.
+type vocabularyRegistrar struct {
+	scenario   *godog.ScenarioContext
+	vocabulary vocabulary
+}
+
+func (r vocabularyRegistrar) Step(expr string, _ stepArgs, stepFunc interface{}) {
+	r.scenario.Step(r.vocabulary.pattern(expr), stepFunc)
+}
+
+// WithStepPrefix adds a prefix to all the steps, for example `es: ` turns `there is index "products"` into
+// `es: there is index "products"`. The prefixed steps must start with the prefix, so they do not conflict with the
+// steps of other libraries.
+func WithStepPrefix(prefix string) ManagerOption {
+	return func(m *Manager) {
+		m.vocabulary.prefix = prefix
+	}
+}
+
+// WithInstanceNoun replaces `es` in the `in es "..."` and `of es "..."` phrases, for example `cluster` turns
+// `index "products" is created in es "search"` into `index "products" is created in cluster "search"`.
+func WithInstanceNoun(noun string) ManagerOption {
+	return func(m *Manager) {
+		m.vocabulary.instanceNoun = noun
+	}
+}
+
+// WithStepTranslations replaces the patterns of the steps, for example to write the features in another language.
+// The keys are the default patterns, as listed by Manager.Steps() without any option, and the values are the new
+// patterns. A new pattern must capture the same arguments in the same order. The instance noun is not applied to the
+// translated patterns, the prefix is.
+func WithStepTranslations(translations map[string]string) ManagerOption {
+	return func(m *Manager) {
+		if m.vocabulary.translations == nil {
+			m.vocabulary.translations = make(map[string]string, len(translations))
+		}
+
+		for k, v := range translations {
+			m.vocabulary.translations[k] = v
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package elasticsteps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVocabulary_pattern(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario   string
+		vocabulary vocabulary
+		pattern    string
+		expected   string
+	}{
+		{
+			scenario: "default",
+			pattern:  `index "([^"]*)" is created in es "([^"]*)"$`,
+			expected: `index "([^"]*)" is created in es "([^"]*)"$`,
+		},
+		{
+			scenario:   "prefix",
+			vocabulary: vocabulary{prefix: "es. "},
+			pattern:    `there is (?:an )?index "([^"]*)"$`,
+			expected:   `^es\. there is (?:an )?index "([^"]*)"$`,
+		},
+		{
+			scenario:   "instance noun",
+			vocabulary: vocabulary{instanceNoun: "cluster"},
+			pattern:    `doc "([^"]*)" is deleted from index "([^"]*)" of es "([^"]*)"$`,
+			expected:   `doc "([^"]*)" is deleted from index "([^"]*)" of cluster "([^"]*)"$`,
+		},
+		{
+			scenario:   "instance noun at the start",
+			vocabulary: vocabulary{instanceNoun: "cluster"},
+			pattern:    `es is healthy$`,
+			expected:   `cluster is healthy$`,
+		},
+		{
+			scenario: "translation",
+			vocabulary: vocabulary{
+				prefix:       "es: ",
+				instanceNoun: "cluster",
+				translations: map[string]string{
+					`index "([^"]*)" is created in es "([^"]*)"$`: `l'index "([^"]*)" est créé dans es "([^"]*)"$`,
+				},
+			},
+			pattern:  `index "([^"]*)" is created in es "([^"]*)"$`,
+			expected: `^es: l'index "([^"]*)" est créé dans es "([^"]*)"$`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.vocabulary.pattern(tc.pattern))
+		})
+	}
+}
+
+func TestManager_Steps_Vocabulary(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(nil,
+		WithStepPrefix("search. "),
+		WithInstanceNoun("cluster"),
+		WithStepTranslations(map[string]string{
+			`index "([^"]*)" exists$`: `l'index "([^"]*)" existe$`,
+		}),
+	)
+
+	steps := m.Steps()
+
+	assert.Contains(t, steps, StepDefinition{
+		Kind:    StepPrerequisite,
+		Pattern: `^search\. index "([^"]*)" is created in cluster "([^"]*)"$`,
+		Arguments: []StepArgument{
+			{Name: "index", Type: "string"},
+			{Name: "instance", Type: "string"},
+		},
+		Example: `Given search. index "<index>" is created in cluster "<instance>"`,
+	})
+
+	assert.Contains(t, steps, StepDefinition{
+		Kind:      StepAssertion,
+		Pattern:   `^search\. l'index "([^"]*)" existe$`,
+		Arguments: []StepArgument{{Name: "index", Type: "string"}},
+		Example:   `Then search. l'index "<index>" existe`,
+	})
+}
+
+func TestManager_InstanceNoun_Errors(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(
+		mockClient(func(c *client) {
+			c.On("ClusterHealth", mock.Anything).
+				Return("red", nil)
+		})(t),
+		WithInstanceNoun("cluster"),
+	)
+
+	_, err := m.client("extra")
+
+	assert.ErrorIs(t, err, ErrInstanceNotFound)
+	assert.EqualError(t, err, `instance not found: cluster "extra", registered: _default`)
+
+	err = m.WaitReady(context.Background(), 10*time.Millisecond)
+
+	assert.EqualError(t, err, `cluster "_default" is not ready: status red`)
+}